@@ -0,0 +1,34 @@
+// Создаёт файл базы данных со свежей схемой. Тестовые данные в созданную базу
+// записывает скрипт internal/aerodb/scripts/createaero.py.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"hw-sqlite3/internal/aerodb"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "No filename in arguments")
+		os.Exit(1)
+	}
+	fname := os.Args[1]
+
+	// Старая база пересоздаётся заново
+	if err := os.Remove(fname); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	db := aerodb.AeroDB{}
+	if err := db.CreateDB(fname); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if err := db.CloseDB(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
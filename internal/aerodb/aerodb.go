@@ -1,10 +1,181 @@
 package aerodb
 
 import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
 	_ "github.com/mattn/go-sqlite3"
 )
 
+var _ Sqlite3DB = (*AeroDB)(nil)
+
 type AeroDB struct {
-	// Start here
+	db *sql.DB
+
+	// Не применять миграции при открытии базы, их можно применить вызовом Migrate.
+	// Базы с более новой схемой не открываются в любом случае.
+	ManualMigrate bool
+
+	// Источник текущего времени, по умолчанию time.Now
+	Clock func() time.Time
+
+	// Дополнительные правила расписания, проверяемые в PlanTrip (nil - не проверять)
+	Rules *ScheduleRules
+
+	// Правила бронирования, проверяемые при продаже билетов (nil - не проверять)
+	Booking *BookingRules
+
+	// Перебронирование: на сколько процентов сверх числа мест компания может
+	// продавать билеты без мест (SellTicket). Компании без записи продают строго по местам.
+	Overbooking map[string]int
+
+	// Вызывается после того, как пассажир из очереди ожидания получил освободившееся место
+	OnPromote func(Promotion)
+
+	// Окно регистрации на поездку, по умолчанию (nil) - от 24 часов до 40 минут до вылета
+	CheckInWindow *CheckInWindow
 }
 
+func (db *AeroDB) OpenDB(fname string) error {
+	if db.db != nil {
+		db.CloseDB()
+	}
+
+	if st, err := os.Stat(fname); err != nil || st.IsDir() {
+		return ErrFile
+	}
+
+	conn, err := openFile(fname, "rw")
+	if err != nil {
+		return err
+	}
+	db.db = conn
+
+	// Схема проверяется и до миграций, чтобы не достраивать таблицы
+	// в чужой файл, и после них
+	err = db.validate()
+	if err == nil && !db.ManualMigrate {
+		err = db.migrate()
+		if err == nil {
+			err = db.validate()
+		}
+	}
+	if err != nil {
+		db.CloseDB()
+		return formatError(err)
+	}
+	return nil
+}
+
+// Создаёт новый файл базы данных со свежей схемой и открывает его
+func (db *AeroDB) CreateDB(fname string) error {
+	f, err := os.OpenFile(fname, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return ErrFile
+	}
+	f.Close()
+
+	manual := db.ManualMigrate
+	db.ManualMigrate = false
+	err = db.OpenDB(fname)
+	db.ManualMigrate = manual
+	if err != nil {
+		os.Remove(fname)
+	}
+	return err
+}
+
+func (db *AeroDB) CloseDB() error {
+	if db.db == nil {
+		return ErrNotOpened
+	}
+	err := db.db.Close()
+	db.db = nil
+	if err != nil {
+		return dbError(err)
+	}
+	return nil
+}
+
+func (db *AeroDB) validate() error {
+	return validateSchema(db.db)
+}
+
+func (db *AeroDB) now() time.Time {
+	if db.Clock != nil {
+		return db.Clock()
+	}
+	return time.Now()
+}
+
+// Выполняет fn в транзакции: при ошибке изменения откатываются
+func (db *AeroDB) inTx(fn func(tx *sql.Tx) error) error {
+	if db.db == nil {
+		return ErrNotOpened
+	}
+	tx, err := db.db.Begin()
+	if err != nil {
+		return dbError(err)
+	}
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return dbError(err)
+	}
+	return nil
+}
+
+func dbError(err error) error {
+	return fmt.Errorf("%w: %w", ErrDB, err)
+}
+
+// Общий интерфейс для *sql.DB и *sql.Tx
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Возвращает id строки по уникальному имени, ErrNotFound если строки нет
+func idByName(q querier, table, name string) (int, error) {
+	var id int
+	err := q.QueryRow("SELECT id FROM "+table+" WHERE name = ?", name).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, dbError(err)
+	}
+	return id, nil
+}
+
+func exists(q querier, query string, args ...interface{}) (bool, error) {
+	var one int
+	err := q.QueryRow("SELECT EXISTS ("+query+")", args...).Scan(&one)
+	if err != nil {
+		return false, dbError(err)
+	}
+	return one == 1, nil
+}
+
+func queryInts(q querier, query string, args ...interface{}) ([]int, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []int
+	for rows.Next() {
+		var n int
+		if err = rows.Scan(&n); err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+	return res, rows.Err()
+}
@@ -1,6 +1,9 @@
 package aerodb
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -20,10 +23,26 @@ func readFile(path string) (string, error) {
 func diffSql(pathDB1, pathDB2 string) (string, error) {
 	cmd := exec.Command("sqldiff", pathDB1, pathDB2)
 	b, err := cmd.Output()
+	// Базы открываются в режиме WAL, sqldiff оставляет рядом с ними файлы журнала
+	for _, path := range []string{pathDB1, pathDB2} {
+		os.Remove(path + "-wal")
+		os.Remove(path + "-shm")
+	}
 	return string(b), err
 }
 
 func createDatabase(fname string) (error) {
+	// Схему создают миграции, скрипт заполняет базу тестовыми данными
+	db := AeroDB{}
+	err := db.OpenDB(fname)
+	if (err != nil) {
+		return err
+	}
+	err = db.CloseDB()
+	if (err != nil) {
+		return err
+	}
+
 	cmd := exec.Command("python3", "./scripts/createaero.py", fname)
 	b, err := cmd.Output()
 	if (string(b) != "") {
@@ -63,6 +82,16 @@ func createTestDataBases() (string, string, error) {
 	return tbase.Name(), tmod.Name(), err
 }
 
+func execSql(path, query string, args ...interface{}) (error) {
+	db, err := sql.Open("sqlite3", path)
+	if (err != nil) {
+		return err
+	}
+	defer db.Close()
+	_, err = db.Exec(query, args...)
+	return err
+}
+
 func readTest(dir string) (out, diff string, err error) {
 	b, err := os.ReadFile(dir + "eout")
 	if (err != nil) {
@@ -78,6 +107,11 @@ func readTest(dir string) (out, diff string, err error) {
 	return out, diff, nil
 }
 
+// Фиксированное время для операций, записывающих текущее время
+func testClock() (time.Time) {
+	return time.Unix(1700000000, 0).UTC()
+}
+
 func errMessage(err error) (string) {
 	if err == nil {
 		return "nil"
@@ -298,7 +332,7 @@ func TestDelPlanePositive(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.DelPlane("AirBus A310")
+	funcErr := db.DelPlane("AirBus A310", "")
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -410,7 +444,7 @@ func TestTakeSeatPositive(t *testing.T) {
 	eout = strings.Trim(eout, "\n")
 
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Clock: testClock}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -470,7 +504,7 @@ func TestEndTripPositive(t *testing.T) {
 	eout = strings.Trim(eout, "\n")
 
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Clock: testClock}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -692,13 +726,13 @@ func TestGetTrips(t *testing.T) {
 	}
 }
 
-// Negative Test 1: AddPassenger
-func TestAddPassengerNegative(t *testing.T) {
-	dir := "tests/neg1/" // Директория с данными для теста
+// Positive test 11: Migrate
+func TestMigratePositive(t *testing.T) {
+	dir := "tests/pos11/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -710,7 +744,7 @@ func TestAddPassengerNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
@@ -724,7 +758,12 @@ func TestAddPassengerNegative(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.AddPassenger("Batman")
+	funcErr := db.Migrate()
+	version, err := db.SchemaVersion()
+	if (err != nil) {
+		t.Errorf("Cannot get schema version: %v", err.Error())
+		return
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -732,6 +771,52 @@ func TestAddPassengerNegative(t *testing.T) {
 		return
 	}
 
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) + "\n" + fmt.Sprint(version == LatestSchemaVersion())
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 12: ValidateDB
+func TestValidateDBPositive(t *testing.T) {
+	dir := "tests/pos12/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Тестовое действие
+	funcErr := ValidateDB(tmod)
+
 	// Получение вывода в строковом формате
 	out := errMessage(funcErr) 
 	// Сравнение полученной базы данных исходной
@@ -752,13 +837,13 @@ func TestAddPassengerNegative(t *testing.T) {
 	}
 }
 
-// Negative Test 2: AddCompany
-func TestAddCompanyNegative(t *testing.T) {
-	dir := "tests/neg2/" // Директория с данными для теста
+// Positive test 13: NewTrip
+func TestNewTripPositive(t *testing.T) {
+	dir := "tests/pos13/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -770,7 +855,7 @@ func TestAddCompanyNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
@@ -784,7 +869,16 @@ func TestAddCompanyNegative(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.AddCompany("S7")
+	trip, funcErr := NewTrip(0, 1, 3, time.Unix(1707642000, 0).UTC(), time.Unix(1707661680, 0).UTC(), "Moscow", "Tokyo")
+	if (funcErr == nil) {
+		_, funcErr = db.PlanTrip(trip)
+	}
+	arr, _ := db.GetTrips("Moscow", "Tokyo")
+	s := ""
+	for _, el := range arr {
+		b, _ := json.Marshal(el)
+		s += "\n" + string(b)
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -793,7 +887,7 @@ func TestAddCompanyNegative(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) 
+	out := errMessage(funcErr) + s
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -812,13 +906,13 @@ func TestAddCompanyNegative(t *testing.T) {
 	}
 }
 
-// Negative Test 3: AddPlane in not existing company
-func TestAddPlaneNegative1(t *testing.T) {
-	dir := "tests/neg3/" // Директория с данными для теста
+// Positive test 14: SetTripState
+func TestSetTripStatePositive(t *testing.T) {
+	dir := "tests/pos14/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -830,13 +924,13 @@ func TestAddPlaneNegative1(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Clock: testClock}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -844,7 +938,12 @@ func TestAddPlaneNegative1(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.AddPlane("Antosha", "Anta", 192)
+	funcErr := db.SetTripState(1, StateBoarding)
+	arr, _ := db.GetTripsByState(StateBoarding)
+	s := ""
+	for _, el := range arr {
+		s += "\n" + fmt.Sprint(el.ID(), " ", el.State(), " ", el.StateTime(StateBoarding).Unix())
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -853,7 +952,7 @@ func TestAddPlaneNegative1(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) 
+	out := errMessage(funcErr) + s
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -872,13 +971,13 @@ func TestAddPlaneNegative1(t *testing.T) {
 	}
 }
 
-// Negative Test 4: AddPlane existing
-func TestAddPlaneNegative2(t *testing.T) {
-	dir := "tests/neg4/" // Директория с данными для теста
+// Positive test 15: PurgeTrip
+func TestPurgeTripPositive(t *testing.T) {
+	dir := "tests/pos15/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -890,7 +989,7 @@ func TestAddPlaneNegative2(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
@@ -904,7 +1003,7 @@ func TestAddPlaneNegative2(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.AddPlane("AirBus A319", "S7", 100)
+	funcErr := db.PurgeTrip(1)
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -932,13 +1031,13 @@ func TestAddPlaneNegative2(t *testing.T) {
 	}
 }
 
-// Negative Test 5: DelPlane existing
-func TestDelPlaneNegative(t *testing.T) {
-	dir := "tests/neg5/" // Директория с данными для теста
+// Positive test 16: PlanTrip over cancelled trip
+func TestPlanTripCancelledPositive(t *testing.T) {
+	dir := "tests/pos16/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -950,13 +1049,13 @@ func TestDelPlaneNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Clock: testClock}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -964,7 +1063,11 @@ func TestDelPlaneNegative(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.DelPlane("Antosha")
+	funcErr := db.SetTripState(2, StateCancelled)
+	if (funcErr == nil) {
+		trip := CreateTrip(0, 1, 1, time.Date(2023, 10, 25, 20, 0, 0, 0, time.UTC), time.Date(2023, 10, 25, 23, 0, 0, 0, time.UTC), "Moscow", "Berlin")
+		_, funcErr = db.PlanTrip(trip)
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -992,13 +1095,13 @@ func TestDelPlaneNegative(t *testing.T) {
 	}
 }
 
-// Negative Test 6: DelCompany not existing
-func TestDelCompanyNegative(t *testing.T) {
-	dir := "tests/neg6/" // Директория с данными для теста
+// Positive test 17: PlanTrip with schedule rules
+func TestPlanTripRulesPositive(t *testing.T) {
+	dir := "tests/pos17/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1010,13 +1113,13 @@ func TestDelCompanyNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Rules: &ScheduleRules{Positioning: true, Turnaround: time.Hour}}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -1024,7 +1127,8 @@ func TestDelCompanyNegative(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.DelCompany("Damn", "S7")
+	trip := CreateTrip(0, 1, 1, time.Date(2023, 10, 26, 3, 0, 0, 0, time.UTC), time.Date(2023, 10, 26, 12, 0, 0, 0, time.UTC), "New-york", "Moscow")
+	_, funcErr := db.PlanTrip(trip)
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1052,13 +1156,13 @@ func TestDelCompanyNegative(t *testing.T) {
 	}
 }
 
-// Negative Test 7: DelCompany no heritant
-func TestDelCompanyNegative2(t *testing.T) {
-	dir := "tests/neg7/" // Директория с данными для теста
+// Positive test 18: CheckSchedule
+func TestCheckSchedulePositive(t *testing.T) {
+	dir := "tests/pos18/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1070,11 +1174,21 @@ func TestDelCompanyNegative2(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
+	// В обе базы добавляется поездка, нарушающая правила расписания
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `INSERT INTO Trip(company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (1, 1, '2023-10-26 02:00:00', '2023-10-26 04:00:00', 'Berlin', 'Paris')`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
 	// Начало теста
 	db := AeroDB{}
 	err = db.OpenDB(tmod)
@@ -1084,7 +1198,11 @@ func TestDelCompanyNegative2(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.DelCompany("S7", "Damn")
+	violations, funcErr := db.CheckSchedule(ScheduleRules{Positioning: true, Turnaround: time.Hour})
+	s := ""
+	for _, v := range violations {
+		s += "\n" + v.String()
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1093,7 +1211,7 @@ func TestDelCompanyNegative2(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) 
+	out := errMessage(funcErr) + s
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -1112,13 +1230,13 @@ func TestDelCompanyNegative2(t *testing.T) {
 	}
 }
 
-// Negative Test 8: Takeseat taken
-func TestTakeSeatNegative1(t *testing.T) {
-	dir := "tests/neg8/" // Директория с данными для теста
+// Positive test 19: FindRoutes
+func TestFindRoutesPositive(t *testing.T) {
+	dir := "tests/pos19/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1130,7 +1248,7 @@ func TestTakeSeatNegative1(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
@@ -1144,7 +1262,14 @@ func TestTakeSeatNegative1(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.TakeSeat(1, "Batman", 11)
+	routes, funcErr := db.FindRoutes("Moscow", "Saint-petersburg", RouteOptions{})
+	s := ""
+	for _, r := range routes {
+		s += "\n" + r.String()
+		for _, leg := range r.Legs {
+			s += fmt.Sprintf(" [%d: %d]", leg.Trip.ID(), leg.FreeSeats)
+		}
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1153,7 +1278,7 @@ func TestTakeSeatNegative1(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) 
+	out := errMessage(funcErr) + s
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -1172,13 +1297,13 @@ func TestTakeSeatNegative1(t *testing.T) {
 	}
 }
 
-// Negative Test 9: Takeseat incorrect tripid
-func TestTakeSeatNegative2(t *testing.T) {
-	dir := "tests/neg9/" // Директория с данными для теста
+// Positive test 20: SearchTrips
+func TestSearchTripsPositive(t *testing.T) {
+	dir := "tests/pos20/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1190,7 +1315,7 @@ func TestTakeSeatNegative2(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
@@ -1204,7 +1329,17 @@ func TestTakeSeatNegative2(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.TakeSeat(8, "Batman", 10)
+	page, funcErr := db.SearchTrips(TripQuery{
+		DepartAfter: time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+		MinFreeSeats: 50,
+		OrderBy: TripsByDeparture,
+		Limit: 2,
+		Offset: 1,
+	})
+	s := fmt.Sprint("\ntotal ", page.Total)
+	for _, el := range page.Trips {
+		s += fmt.Sprint(" ", el.ID())
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1213,7 +1348,7 @@ func TestTakeSeatNegative2(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) 
+	out := errMessage(funcErr) + s
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -1232,13 +1367,13 @@ func TestTakeSeatNegative2(t *testing.T) {
 	}
 }
 
-// Negative Test 10: Takeseat incorrect passenger
-func TestTakeSeatNegative3(t *testing.T) {
-	dir := "tests/neg10/" // Директория с данными для теста
+// Positive test 21: IterAllTrips
+func TestIterAllTripsPositive(t *testing.T) {
+	dir := "tests/pos21/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1250,7 +1385,7 @@ func TestTakeSeatNegative3(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
@@ -1264,7 +1399,14 @@ func TestTakeSeatNegative3(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.TakeSeat(1, "Antosha", 10)
+	s := ""
+	it, funcErr := db.IterAllTrips()
+	if (funcErr == nil) {
+		funcErr = it.ForEach(func(trip Trip) bool {
+			s += fmt.Sprint(" ", trip.ID())
+			return trip.ID() < 3
+		})
+	}
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1273,7 +1415,7 @@ func TestTakeSeatNegative3(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) 
+	out := errMessage(funcErr) + "\n" + strings.TrimSpace(s)
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -1292,13 +1434,13 @@ func TestTakeSeatNegative3(t *testing.T) {
 	}
 }
 
-// Negative Test 11: Takeseat incorrect seat
-func TestTakeSeatNegative4(t *testing.T) {
-	dir := "tests/neg11/" // Директория с данными для теста
+// Positive test 22: ReleaseSeat
+func TestReleaseSeatPositive(t *testing.T) {
+	dir := "tests/pos22/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1310,7 +1452,7 @@ func TestTakeSeatNegative4(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
@@ -1324,7 +1466,7 @@ func TestTakeSeatNegative4(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.TakeSeat(1, "Batman", 1000)
+	funcErr := db.ReleaseSeat(1, 11)
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1352,13 +1494,13 @@ func TestTakeSeatNegative4(t *testing.T) {
 	}
 }
 
-// Negative Test 12: EndTrip
-func TestEndTripNegative(t *testing.T) {
-	dir := "tests/neg12/" // Директория с данными для теста
+// Positive test 23: ChangeSeat
+func TestChangeSeatPositive(t *testing.T) {
+	dir := "tests/pos23/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1370,13 +1512,13 @@ func TestEndTripNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Clock: testClock}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -1384,7 +1526,7 @@ func TestEndTripNegative(t *testing.T) {
 	}
 	
 	// Тестовое действие
-	funcErr := db.EndTrip(10)
+	funcErr := db.ChangeSeat(3, "Runmbert", 10)
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1412,13 +1554,13 @@ func TestEndTripNegative(t *testing.T) {
 	}
 }
 
-// Negative Test 13: PlanTrip incorrect time
-func TestPlaneTripNegative(t *testing.T) {
-	dir := "tests/neg13/" // Директория с данными для теста
+// Positive test 24: HoldSeat and ConfirmHold
+func TestHoldSeatPositive(t *testing.T) {
+	dir := "tests/pos24/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1430,13 +1572,16 @@ func TestPlaneTripNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
+	// Время теста, которое можно сдвигать
+	now := testClock()
+
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Clock: func() time.Time { return now }}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -1444,9 +1589,9 @@ func TestPlaneTripNegative(t *testing.T) {
 	}
 	
 	// Тестовое действие
-
-	trip := CreateTrip(0, 1, 3, time.Unix(1707662680, 0), time.Unix(1707661680, 0), "Moscow", "Tokyo")
-	_, funcErr := db.PlanTrip(trip)
+	token, holdErr := db.HoldSeat(1, 12, 10*time.Minute)
+	now = now.Add(5 * time.Minute)
+	funcErr := db.ConfirmHold(token, "Batman")
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1455,7 +1600,7 @@ func TestPlaneTripNegative(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) 
+	out := errMessage(holdErr) + " " + errMessage(funcErr)
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -1474,15 +1619,13 @@ func TestPlaneTripNegative(t *testing.T) {
 	}
 }
 
-
-
-// Negative Test 14: GetTrips
-func TestGetTripsNegative(t *testing.T) {
-	dir := "tests/neg14/" // Директория с данными для теста
+// Positive test 25: Expired seat hold
+func TestHoldSeatExpiredPositive(t *testing.T) {
+	dir := "tests/pos25/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1494,13 +1637,16 @@ func TestGetTripsNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
+	// Время теста, которое можно сдвигать
+	now := testClock()
+
 	// Начало теста
-	db := AeroDB{}
+	db := AeroDB{Clock: func() time.Time { return now }}
 	err = db.OpenDB(tmod)
 	if (err != nil) {
 		t.Errorf("Cannot open database: %v", err.Error())
@@ -1508,12 +1654,12 @@ func TestGetTripsNegative(t *testing.T) {
 	}
 	
 	// Тестовое действие
-
-	arr, funcErr := db.GetTrips("Tokyo", "New-york")
-	s := ""
-	for _, el := range arr {
-		s += "\n" + fmt.Sprint(el)
-	}
+	token, _ := db.HoldSeat(1, 12, time.Minute)
+	held, _ := db.GetFreeSeats(1)
+	now = now.Add(2 * time.Minute)
+	expired, _ := db.GetFreeSeats(1)
+	takeErr := db.TakeSeat(1, "Batman", 12)
+	funcErr := db.ReleaseHold(token)
 
 	err = db.CloseDB()
 	if (err != nil) {
@@ -1522,7 +1668,7 @@ func TestGetTripsNegative(t *testing.T) {
 	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr) + s
+	out := fmt.Sprintf("%d %d %s %s", len(held), len(expired), errMessage(takeErr), errMessage(funcErr))
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -1541,13 +1687,13 @@ func TestGetTripsNegative(t *testing.T) {
 	}
 }
 
-// Negative Test 15: CloseDb unopened
-func TestCloseDBNegative(t *testing.T) {
-	dir := "tests/neg15/" // Директория с данными для теста
+// Positive test 26: BookGroup
+func TestBookGroupPositive(t *testing.T) {
+	dir := "tests/pos26/" // Директория с данными для теста
 	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
 	// тестовую базу данных
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
 		return
 	}
 	// Удаляем временные файлы
@@ -1559,18 +1705,34 @@ func TestCloseDBNegative(t *testing.T) {
 	// ediff - отличие базы данных после выполнения операции от стандартной
 	eout, ediff, err := readTest(dir)
 	if (err != nil) {
-		t.Error(err)
+		t.Errorf("Error while fetching test data: %v", err.Error())
 		return
 	}
 	eout = strings.Trim(eout, "\n")
 
 	// Начало теста
-	db := AeroDB{}	
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
 	// Тестовое действие
-	funcErr := db.CloseDB()
+	booked, funcErr := db.BookGroup(1, []GroupSeat{
+		{Passenger: "Batman", Seat: 12},
+		{Passenger: "Batgirl"},
+		{Passenger: "Runmbert"},
+	})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
 
 	// Получение вывода в строковом формате
-	out := errMessage(funcErr)
+	out := fmt.Sprintf("%v %s", booked, errMessage(funcErr))
 	// Сравнение полученной базы данных исходной
 	diff, err := diffSql(tbase, tmod)
 	
@@ -1587,4 +1749,4484 @@ func TestCloseDBNegative(t *testing.T) {
 	if (diff != ediff) {
 		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
 	}
-}
\ No newline at end of file
+}
+
+// Positive test 27: SetSeatLayout
+func TestSetSeatLayoutPositive(t *testing.T) {
+	dir := "tests/pos27/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.SetSeatLayout("Tupolev", SeatLayout{
+		Cabins: []CabinLayout{
+			{Cabin: CabinBusiness, Rows: 1, Letters: "AB CD"},
+			{Cabin: CabinEconomy, Rows: 2, Letters: "ABC DEF", ExitRows: []int{2}},
+		},
+		Blocked: []string{"3F"},
+	})
+	seatMap, _ := db.GetSeatMap("Tupolev")
+	labels := make([]string, len(seatMap))
+	for i, s := range seatMap {
+		labels[i] = s.String()
+	}
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) + "\n" + strings.Join(labels, "\n")
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 28: TakeSeatLabel and GetFreeSeatsBy
+func TestTakeSeatLabelPositive(t *testing.T) {
+	dir := "tests/pos28/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeatLabel(1, "Batman", "3a")
+	windows, _ := db.GetFreeSeatsBy(1, SeatFilter{Cabin: CabinEconomy, Attrs: SeatWindow})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %d %v", errMessage(funcErr), len(windows), windows[0])
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 29: Waitlist promotion
+func TestWaitlistPositive(t *testing.T) {
+	dir := "tests/pos29/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется полностью занятая поездка самолёта на два места
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2024-02-01 10:00:00+00:00', '2024-02-01 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	var promoted []Promotion
+	db.OnPromote = func(p Promotion) { promoted = append(promoted, p) }
+	db.JoinWaitlist(6, "Batgirl", "")
+	db.JoinWaitlist(6, "Runmbert", CabinEconomy)
+	db.MoveInWaitlist(6, "Runmbert", 1)
+	funcErr := db.ReleaseSeat(6, 1)
+	rest, _ := db.GetWaitlist(6)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v %v", errMessage(funcErr), promoted, rest)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 30: Overbooking
+func TestSellTicketPositive(t *testing.T) {
+	dir := "tests/pos30/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется полностью занятая поездка самолёта на два места
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2024-02-01 10:00:00+00:00', '2024-02-01 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock, Overbooking: map[string]int{"Aeroflot": 50}}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.SellTicket(6, "Batgirl")
+	limitErr := db.SellTicket(6, "Runmbert")
+	denied, _ := db.DeniedBoarding(6)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s\n%s\n%v", errMessage(funcErr), errMessage(limitErr), denied)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 31: Fares and revenue
+func TestFaresPositive(t *testing.T) {
+	dir := "tests/pos31/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	base, _ := ParseMoney("5000")
+	funcErr := db.SetFares(1, []CabinFare{
+		{Cabin: CabinEconomy, Base: base, Tiers: []PriceTier{{LoadFactor: 15, Price: 750000}, {LoadFactor: 50, Price: 1000000}}},
+		{Cabin: CabinBusiness, Base: 2000000},
+	})
+	price, _ := db.SeatPrice(1, 12)
+	takeErr := db.TakeSeat(1, "Batman", 12)
+	tripRevenue, _ := db.TripRevenue(1)
+	companyRevenue, _ := db.CompanyRevenue("S7", time.Time{}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v %s\n%v\n%v", errMessage(funcErr), price, errMessage(takeErr), tripRevenue, companyRevenue)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 32: Passengers with the same name
+func TestAddPassengerInfoPositive(t *testing.T) {
+	dir := "tests/pos32/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	first, funcErr := db.AddPassengerInfo(PassengerInfo{GivenName: "Mark", FamilyName: "Twain",
+		BirthDate: time.Date(1990, 5, 17, 0, 0, 0, 0, time.UTC), DocType: "passport", DocNumber: "4510 123456"})
+	second, _ := db.AddPassengerInfo(PassengerInfo{GivenName: "Mark", FamilyName: "Twain",
+		DocType: "passport", DocNumber: "4510 654321", Contact: "+7 900 000-00-00"})
+	takeErr := db.TakeSeatByID(1, second, 12)
+	nameErr := db.TakeSeat(1, "Mark Twain", 13)
+	found, _ := db.FindPassengerByDocument("passport", "4510 123456")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %d %d %s\n%s\n%v %s", errMessage(funcErr), first, second, errMessage(takeErr), errMessage(nameErr), found, found.BirthDate.Format(time.DateOnly))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 33: Booking rules
+func TestBookingRulesPositive(t *testing.T) {
+	dir := "tests/pos33/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock, Booking: &BookingRules{OneTicketPerTrip: true, NoOverlap: true}}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeat(1, "Deineris", 12)
+	extraErr := db.TakeExtraSeat(1, "Deineris", 13)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %s", errMessage(funcErr), errMessage(extraErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 34: Passenger trips
+func TestGetPassengerTripsPositive(t *testing.T) {
+	dir := "tests/pos34/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	all, funcErr := db.GetPassengerTrips("Batgirl", PassengerTripFilter{})
+	s7, _ := db.GetPassengerTrips("Batgirl", PassengerTripFilter{Company: "S7"})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v %v %v", errMessage(funcErr), all.Upcoming, all.Past, s7.Upcoming)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 35: Trip manifest
+func TestGetManifestPositive(t *testing.T) {
+	dir := "tests/pos35/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка с одним билетом, проданным до записи времени продажи
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2024-02-01 10:00:00+00:00', '2024-02-01 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 2);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	db.SellTicket(6, "Batgirl")
+	manifest, funcErr := db.GetManifest(6)
+	var csv, js strings.Builder
+	manifest.WriteCSV(&csv)
+	manifest.WriteJSON(&js)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s\n%s%s", errMessage(funcErr), csv.String(), strings.TrimSpace(js.String()))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 36: Check-in
+func TestCheckInPositive(t *testing.T) {
+	dir := "tests/pos36/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка, регистрация на которую открыта, с билетом на месте и билетом без места
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2023-11-15 10:00:00+00:00', '2023-11-15 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 2), (6, 2, NULL);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	db.SetGate(6, "A5")
+	pass, funcErr := db.CheckIn(6, "Superman")
+	seatless, _ := db.CheckIn(6, "Batman")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v|%s|%v\n%s", errMessage(funcErr), pass, pass.BCBP(), seatless, strings.TrimSpace(pass.Text()))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 37: Boarding and no-shows
+func TestCloseBoardingPositive(t *testing.T) {
+	dir := "tests/pos37/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка на посадке с двумя занятыми местами и очередью ожидания
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in, state)
+			VALUES (6, 1, 3, '2023-11-14 23:00:00+00:00', '2023-11-15 01:00:00+00:00', 'Moscow', 'Kazan', 'boarding');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);
+			INSERT INTO Waitlist(trip_id, passenger_id, cabin, position) VALUES (6, 3, '', 1);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	db.MarkBoarded(6, "Superman")
+	count, _ := db.BoardingCount(6)
+	report, funcErr := db.CloseBoarding(6)
+	history, _ := db.GetPassengerNoShows("Batman")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v %v %v", errMessage(funcErr), count, report, history)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 38: DelCompany report
+func TestDelCompanyReportPositive(t *testing.T) {
+	dir := "tests/pos38/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	dry, funcErr := db.DelCompanyReport("S7", "Red Wings", DelCompanyOptions{DryRun: true})
+	report, _ := db.DelCompanyReport("Victory", "", DelCompanyOptions{})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v; %v", errMessage(funcErr), dry, report)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 39: DelPlane with reseating
+func TestDelPlaneReportPositive(t *testing.T) {
+	dir := "tests/pos39/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обеих базах у самолёта-наследника меньше мест, чем номера некоторых занятых мест
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 90 WHERE name = 'Ty-214';`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	report, funcErr := db.DelPlaneReport("Sukhoi SSJ 100", "Ty-214", DelPlaneOptions{Remap: RemapByLabel})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v", errMessage(funcErr), report)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 40: ReassignPlane
+func TestReassignPlanePositive(t *testing.T) {
+	dir := "tests/pos40/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	changes, funcErr := db.ReassignPlane(4, "AirBus A320", RemapByOrder)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v", errMessage(funcErr), changes)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 41: Seat standby passenger after CloseBoarding
+func TestCloseBoardingStandbyPositive(t *testing.T) {
+	dir := "tests/pos41/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется полная поездка на посадке из двух мест
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in, state)
+			VALUES (6, 1, 3, '2023-11-14 23:00:00+00:00', '2023-11-15 01:00:00+00:00', 'Moscow', 'Kazan', 'boarding');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	db.MarkBoarded(6, "Superman")
+	db.CloseBoarding(6)
+	free, _ := db.GetFreeSeats(6)
+	funcErr := db.TakeSeat(6, "Batgirl", 2)
+	count, _ := db.BoardingCount(6)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v %v", errMessage(funcErr), free, count)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 42: DelPlaneReport keeps seats of arrived trips
+func TestDelPlaneReportArrivedPositive(t *testing.T) {
+	dir := "tests/pos42/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обеих базах поездка удаляемого самолёта завершена, а у наследника меньше мест,
+	// чем номера некоторых занятых мест
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 90 WHERE name = 'Ty-214';
+			UPDATE Trip SET state = 'arrived' WHERE id = 4;`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	report, funcErr := db.DelPlaneReport("Sukhoi SSJ 100", "Ty-214", DelPlaneOptions{Remap: RemapByOrder})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v", errMessage(funcErr), report)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 43: Waitlist and seat labels by passenger ID
+func TestWaitlistByIDPositive(t *testing.T) {
+	dir := "tests/pos43/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляются полностью занятая поездка самолёта на два места
+	// и два пассажира с одинаковыми именами
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2024-02-01 10:00:00+00:00', '2024-02-01 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);
+			INSERT INTO Passenger(id, name) VALUES (29, 'Mark'), (30, 'Mark');`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	var promoted []Promotion
+	db.OnPromote = func(p Promotion) { promoted = append(promoted, p) }
+	db.JoinWaitlistByID(6, 29, "")
+	db.JoinWaitlistByID(6, 30, "")
+	db.JoinWaitlistByID(6, 3, "")
+	db.MoveInWaitlistByID(6, 30, 1)
+	db.LeaveWaitlistByID(6, 29)
+	before, _ := db.GetWaitlist(6)
+	db.ReleaseSeat(6, 2)
+	funcErr := db.TakeSeatLabelByID(1, 29, "2A")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v %v", errMessage(funcErr), before, promoted)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 44: Waitlist promotion skips passengers failing booking rules
+func TestWaitlistBookingRulesPositive(t *testing.T) {
+	dir := "tests/pos44/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляются полностью занятая поездка самолёта на два места
+	// и поездка другого самолёта в то же время
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2024-02-01 10:00:00+00:00', '2024-02-01 12:00:00+00:00', 'Moscow', 'Kazan'),
+			(7, 1, 2, '2024-02-01 11:00:00+00:00', '2024-02-01 13:00:00+00:00', 'Moscow', 'Sochi');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock, Booking: &BookingRules{NoOverlap: true}}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	var promoted []Promotion
+	db.OnPromote = func(p Promotion) { promoted = append(promoted, p) }
+	db.JoinWaitlist(6, "Batgirl", "")
+	db.JoinWaitlist(6, "Runmbert", "")
+	db.TakeSeat(7, "Batgirl", 1)
+	funcErr := db.ReleaseSeat(6, 1)
+	rest, _ := db.GetWaitlist(6)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v %v", errMessage(funcErr), promoted, rest)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 45: Iterators over passenger trips, no-shows and waitlist
+func TestIterPassengerListsPositive(t *testing.T) {
+	dir := "tests/pos45/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка на посадке с двумя занятыми местами и очередью ожидания
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in, state)
+			VALUES (6, 1, 3, '2023-11-14 23:00:00+00:00', '2023-11-15 01:00:00+00:00', 'Moscow', 'Kazan', 'boarding');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);
+			INSERT INTO Waitlist(trip_id, passenger_id, cabin, position) VALUES (6, 3, '', 1), (6, 4, '', 2);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	db.MarkBoarded(6, "Superman")
+	db.CloseBoarding(6)
+	s := ""
+	trips, funcErr := db.IterPassengerTripsByID(1, PassengerTripFilter{})
+	if (funcErr == nil) {
+		funcErr = trips.ForEach(func(pt PassengerTrip) bool {
+			s += "\n" + pt.String()
+			return pt.Trip.ID() != 6
+		})
+	}
+	noShows, _ := db.IterPassengerNoShows("Batman")
+	for noShows.Next() {
+		s += "\n" + noShows.NoShow().String()
+	}
+	waitlist, _ := db.IterWaitlist(6)
+	for waitlist.Next() {
+		s += "\n" + fmt.Sprint(waitlist.Entry())
+	}
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) + s
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 46: Free seats respect seatless tickets
+func TestFreeSeatsOverbookedPositive(t *testing.T) {
+	dir := "tests/pos46/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка самолёта на два места с тремя билетами без мест
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2024-02-01 10:00:00+00:00', '2024-02-01 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, NULL), (6, 2, NULL), (6, 3, NULL);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock, Overbooking: map[string]int{"Aeroflot": 50}}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, freeErr := db.GetFreeSeats(6)
+	_, searchErr := db.SearchTrips(TripQuery{From: "Moscow", To: "Kazan", MinFreeSeats: 1})
+	takeErr := db.TakeSeat(6, "John Snow", 1)
+	funcErr := db.JoinWaitlist(6, "John Snow", "")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s | %s | %s | %s", errMessage(funcErr), errMessage(freeErr), errMessage(searchErr), errMessage(takeErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 47: CheckSchedule
+func TestCheckScheduleNonAdjacentPositive(t *testing.T) {
+	dir := "tests/pos47/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Длинная поездка самолёта перекрывает две следующие, несоседние между собой
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `INSERT INTO Trip(company_id, plane_id, time_out, time_in, town_out, town_in) VALUES
+			(1, 3, '2024-02-01 00:00:00', '2024-02-01 10:00:00', 'Moscow', 'Paris'),
+			(1, 3, '2024-02-01 01:00:00', '2024-02-01 02:00:00', 'Paris', 'Berlin'),
+			(1, 3, '2024-02-01 03:00:00', '2024-02-01 04:00:00', 'Berlin', 'Rome')`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	violations, funcErr := db.CheckSchedule(ScheduleRules{})
+	s := ""
+	for _, v := range violations {
+		s += "\n" + v.String()
+	}
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) + s
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 48: SetSeatLayout after EndTrip
+func TestSetSeatLayoutEndedTripPositive(t *testing.T) {
+	dir := "tests/pos48/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	endErr := db.EndTrip(1)
+	funcErr := db.SetSeatLayout("AirBus A310", SeatLayout{
+		Cabins:  []CabinLayout{{Cabin: CabinEconomy, Rows: 2, Letters: "ABC DEF"}},
+		Blocked: []string{"2E"},
+	})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(endErr) + " | " + errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 49: EndTrip forced close
+func TestEndTripForcedPositive(t *testing.T) {
+	dir := "tests/pos49/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Посадка на поездку уже началась
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Trip SET state = 'boarding', boarding_at = '2023-11-14 21:00:00+00:00' WHERE id = 1`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	stateErr := db.SetTripState(1, StateArrived)
+	funcErr := db.EndTrip(1)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(stateErr) + " | " + errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Positive test 50: EndTrip while iterating trips
+func TestIterTripsWritePositive(t *testing.T) {
+	dir := "tests/pos50/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Errorf("Error while creating temp files for test: %v", err.Error())
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Errorf("Error while fetching test data: %v", err.Error())
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	trips, funcErr := db.IterAllTrips()
+	s := ""
+	if (funcErr == nil) {
+		for trips.Next() {
+			s += fmt.Sprintf(" %d:%s", trips.Trip().ID(), errMessage(db.EndTrip(trips.Trip().ID())))
+		}
+		funcErr = trips.Close()
+	}
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) + s
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 1: AddPassenger
+func TestAddPassengerNegative(t *testing.T) {
+	dir := "tests/neg1/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.AddPassenger("Batman")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 2: AddCompany
+func TestAddCompanyNegative(t *testing.T) {
+	dir := "tests/neg2/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.AddCompany("S7")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 3: AddPlane in not existing company
+func TestAddPlaneNegative1(t *testing.T) {
+	dir := "tests/neg3/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.AddPlane("Antosha", "Anta", 192)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 4: AddPlane existing
+func TestAddPlaneNegative2(t *testing.T) {
+	dir := "tests/neg4/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.AddPlane("AirBus A319", "S7", 100)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 5: DelPlane existing
+func TestDelPlaneNegative(t *testing.T) {
+	dir := "tests/neg5/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.DelPlane("Antosha", "")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 6: DelCompany not existing
+func TestDelCompanyNegative(t *testing.T) {
+	dir := "tests/neg6/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.DelCompany("Damn", "S7")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 7: DelCompany no heritant
+func TestDelCompanyNegative2(t *testing.T) {
+	dir := "tests/neg7/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.DelCompany("S7", "Damn")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 8: Takeseat taken
+func TestTakeSeatNegative1(t *testing.T) {
+	dir := "tests/neg8/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeat(1, "Batman", 11)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 9: Takeseat incorrect tripid
+func TestTakeSeatNegative2(t *testing.T) {
+	dir := "tests/neg9/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeat(8, "Batman", 10)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 10: Takeseat incorrect passenger
+func TestTakeSeatNegative3(t *testing.T) {
+	dir := "tests/neg10/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeat(1, "Antosha", 10)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 11: Takeseat incorrect seat
+func TestTakeSeatNegative4(t *testing.T) {
+	dir := "tests/neg11/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeat(1, "Batman", 1000)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 12: EndTrip
+func TestEndTripNegative(t *testing.T) {
+	dir := "tests/neg12/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.EndTrip(10)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 13: PlanTrip incorrect time
+func TestPlaneTripNegative(t *testing.T) {
+	dir := "tests/neg13/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+
+	trip := CreateTrip(0, 1, 3, time.Unix(1707662680, 0), time.Unix(1707661680, 0), "Moscow", "Tokyo")
+	_, funcErr := db.PlanTrip(trip)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) 
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+
+
+// Negative Test 14: GetTrips
+func TestGetTripsNegative(t *testing.T) {
+	dir := "tests/neg14/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+
+	arr, funcErr := db.GetTrips("Tokyo", "New-york")
+	s := ""
+	for _, el := range arr {
+		s += "\n" + fmt.Sprint(el)
+	}
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) + s
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 15: CloseDb unopened
+func TestCloseDBNegative(t *testing.T) {
+	dir := "tests/neg15/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}	
+	// Тестовое действие
+	funcErr := db.CloseDB()
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 16: OpenDB with newer schema
+func TestOpenDBNegative(t *testing.T) {
+	dir := "tests/neg16/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Обе базы получают версию схемы новее поддерживаемой
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, "INSERT INTO schema_version(version, name) VALUES (?, 'future')", LatestSchemaVersion() + 1)
+		if (err != nil) {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{}	
+	// Тестовое действие
+	funcErr := db.OpenDB(tmod)
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprint(errors.Is(funcErr, ErrSchemaVersion)) + "\n" + errMessage(db.CloseDB())
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 17: OpenDB with missing index
+func TestOpenDBNegative2(t *testing.T) {
+	dir := "tests/neg17/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Из обеих баз удаляется индекс
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, "DROP INDEX Trip_route")
+		if (err != nil) {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{}	
+	// Тестовое действие
+	funcErr := db.OpenDB(tmod)
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprint(errors.Is(funcErr, ErrDBFormat)) + "\n" + errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 18: NewTrip with empty town
+func TestNewTripNegative(t *testing.T) {
+	dir := "tests/neg18/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Тестовое действие
+	_, funcErr := NewTrip(0, 1, 3, time.Unix(1707642000, 0), time.Unix(1707661680, 0), "Moscow", "")
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 19: SetTripState illegal transition
+func TestSetTripStateNegative(t *testing.T) {
+	dir := "tests/neg19/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.SetTripState(1, StateArrived)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 20: PlanTrip plane is busy
+func TestPlanTripBusyNegative(t *testing.T) {
+	dir := "tests/neg20/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	trip := CreateTrip(0, 1, 1, time.Date(2023, 10, 25, 20, 0, 0, 0, time.UTC), time.Date(2023, 10, 25, 23, 0, 0, 0, time.UTC), "Moscow", "Berlin")
+	_, funcErr := db.PlanTrip(trip)
+	var busy *PlaneBusyError
+	if (errors.As(funcErr, &busy)) {
+		funcErr = fmt.Errorf("%v (trip %d)", funcErr, busy.Trip)
+	}
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 21: PlanTrip breaks schedule rules
+func TestPlanTripRulesNegative(t *testing.T) {
+	dir := "tests/neg21/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Rules: &ScheduleRules{Positioning: true, Turnaround: time.Hour}}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	trip := CreateTrip(0, 1, 1, time.Date(2023, 10, 26, 1, 53, 0, 0, time.UTC), time.Date(2023, 10, 26, 4, 0, 0, 0, time.UTC), "Moscow", "Berlin")
+	_, funcErr := db.PlanTrip(trip)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 22: FindRoutes with short connections
+func TestFindRoutesNegative(t *testing.T) {
+	dir := "tests/neg22/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.FindRoutes("Moscow", "Saint-petersburg", RouteOptions{MaxConnection: 24 * time.Hour})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 23: SearchTrips unknown state
+func TestSearchTripsNegative(t *testing.T) {
+	dir := "tests/neg23/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.SearchTrips(TripQuery{State: "flying"})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 24: IterAllTrips on closed database
+func TestIterAllTripsNegative(t *testing.T) {
+	dir := "tests/neg24/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+	_, funcErr := db.IterAllTrips()
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 25: ChangeSeat to taken seat
+func TestChangeSeatNegative(t *testing.T) {
+	dir := "tests/neg25/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.ChangeSeat(1, "Batman", 11)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 26: ChangeSeat passenger with several seats
+func TestChangeSeatNegative2(t *testing.T) {
+	dir := "tests/neg26/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.ChangeSeat(3, "Anubis", 10)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 27: ConfirmHold after expiry
+func TestConfirmHoldNegative(t *testing.T) {
+	dir := "tests/neg27/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Время теста, которое можно сдвигать
+	now := testClock()
+
+	// Начало теста
+	db := AeroDB{Clock: func() time.Time { return now }}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	token, _ := db.HoldSeat(1, 12, time.Minute)
+	now = now.Add(time.Minute)
+	funcErr := db.ConfirmHold(token, "Batman")
+	db.ReleaseHold(token)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 28: BookGroup with unavailable seats
+func TestBookGroupNegative(t *testing.T) {
+	dir := "tests/neg28/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.BookGroup(1, []GroupSeat{
+		{Passenger: "Batman", Seat: 12},
+		{Passenger: "Batgirl", Seat: 11},
+		{Passenger: "Runmbert", Seat: 500},
+	})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 29: TakeSeatLabel with unknown seat
+func TestTakeSeatLabelNegative(t *testing.T) {
+	dir := "tests/neg29/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeatLabel(1, "Batman", "40A")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 30: SetSeatLayout blocking taken seat
+func TestSetSeatLayoutNegative(t *testing.T) {
+	dir := "tests/neg30/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.SetSeatLayout("AirBus A310", SeatLayout{
+		Cabins:  []CabinLayout{{Cabin: CabinEconomy, Rows: 34, Letters: "ABC DEF"}},
+		Blocked: []string{"2E"},
+	})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 31: JoinWaitlist with free seats
+func TestJoinWaitlistNegative(t *testing.T) {
+	dir := "tests/neg31/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.JoinWaitlist(1, "Batgirl", "")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 32: SellTicket in strict mode
+func TestSellTicketNegative(t *testing.T) {
+	dir := "tests/neg32/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется полностью занятая поездка самолёта на два места
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 2 WHERE name = 'Tupolev';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2024-02-01 10:00:00+00:00', '2024-02-01 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, 1), (6, 2, 2);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.SellTicket(6, "Batgirl")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 33: SetFares with repeated cabin
+func TestSetFaresNegative(t *testing.T) {
+	dir := "tests/neg33/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.SetFares(1, []CabinFare{{Cabin: CabinEconomy, Base: 500000}, {Cabin: CabinEconomy, Base: 600000}})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 34: AddPassengerInfo with taken document
+func TestAddPassengerInfoNegative(t *testing.T) {
+	dir := "tests/neg34/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	db.AddPassengerInfo(PassengerInfo{GivenName: "Mark", FamilyName: "Twain", DocType: "passport", DocNumber: "4510 123456"})
+	_, funcErr := db.AddPassengerInfo(PassengerInfo{GivenName: "Samuel", FamilyName: "Clemens", DocType: "passport", DocNumber: "4510 123456"})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 35: Booking rules
+func TestBookingRulesNegative(t *testing.T) {
+	dir := "tests/neg35/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка, пересекающаяся по времени с поездкой 1
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2023-12-25 16:00:00+00:00', '2023-12-25 17:00:00+00:00', 'Yaroslavl', 'Kazan');`)
+		if (err != nil) {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Booking: &BookingRules{OneTicketPerTrip: true, NoOverlap: true}}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.TakeSeat(1, "Mayor pain", 12)
+	busyErr := db.TakeSeat(6, "Mayor pain", 1)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %s", errMessage(funcErr), errMessage(busyErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 36: Passenger trips
+func TestGetPassengerTripsNegative(t *testing.T) {
+	dir := "tests/neg36/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.GetPassengerTrips("Batgirl", PassengerTripFilter{Company: "Pobeda"})
+	_, nameErr := db.GetPassengerTrips("Nobody", PassengerTripFilter{})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %s", errMessage(funcErr), errMessage(nameErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 37: Trip manifest
+func TestGetManifestNegative(t *testing.T) {
+	dir := "tests/neg37/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.GetManifest(100)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 38: Check-in
+func TestCheckInNegative(t *testing.T) {
+	dir := "tests/neg38/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.CheckIn(1, "Dobby")
+	_, closedErr := db.CheckIn(2, "Anubis")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %s", errMessage(funcErr), errMessage(closedErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 39: Boarding and no-shows
+func TestMarkBoardedNegative(t *testing.T) {
+	dir := "tests/neg39/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.MarkBoarded(1, "Dobby")
+	_, closeErr := db.CloseBoarding(100)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %s", errMessage(funcErr), errMessage(closeErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 40: DelCompany report
+func TestDelCompanyReportNegative(t *testing.T) {
+	dir := "tests/neg40/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.DelCompanyReport("S7", "Pobeda", DelCompanyOptions{DryRun: true})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 41: DelPlane seat conflicts
+func TestDelPlaneReportNegative(t *testing.T) {
+	dir := "tests/neg41/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обеих базах у самолёта-наследника меньше мест, чем номера некоторых занятых мест
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 90 WHERE name = 'Ty-214';`)
+		if (err != nil) {
+			t.Error(err)
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.DelPlane("Sukhoi SSJ 100", "Ty-214")
+	otherErr := db.DelPlane("Sukhoi SSJ 100", "Tupolev")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %s", errMessage(funcErr), errMessage(otherErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 42: ReassignPlane
+func TestReassignPlaneNegative(t *testing.T) {
+	dir := "tests/neg42/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.ReassignPlane(4, "AirBus A320", RemapNone)
+	_, missingErr := db.ReassignPlane(100, "Brother", RemapNone)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s | %s", errMessage(funcErr), errMessage(missingErr))
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 43: DelPlane to a heir with a smaller ticket limit
+func TestDelPlaneLimitNegative(t *testing.T) {
+	dir := "tests/neg43/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка с тремя билетами без мест на трёхместном самолёте,
+	// у другого самолёта компании одно место
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 3 WHERE name = 'Tupolev';
+			UPDATE Plane SET seats = 1 WHERE name = 'Mother';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2023-11-20 10:00:00+00:00', '2023-11-20 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, NULL), (6, 2, NULL), (6, 3, NULL);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	funcErr := db.DelPlane("Tupolev", "Mother")
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 44: ReassignPlane to a plane with a smaller ticket limit
+func TestReassignPlaneLimitNegative(t *testing.T) {
+	dir := "tests/neg44/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// В обе базы добавляется поездка с тремя билетами без мест на трёхместном самолёте,
+	// у другого самолёта компании одно место
+	for _, path := range []string{tbase, tmod} {
+		err = execSql(path, `UPDATE Plane SET seats = 3 WHERE name = 'Tupolev';
+			UPDATE Plane SET seats = 1 WHERE name = 'Mother';
+			INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (6, 1, 3, '2023-11-20 10:00:00+00:00', '2023-11-20 12:00:00+00:00', 'Moscow', 'Kazan');
+			INSERT INTO Taken(trip_id, passenger_id, place) VALUES (6, 1, NULL), (6, 2, NULL), (6, 3, NULL);`)
+		if (err != nil) {
+			t.Errorf("Cannot prepare databases: %v", err.Error())
+			return
+		}
+	}
+
+	// Начало теста
+	db := AeroDB{Clock: testClock}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.ReassignPlane(6, "Mother", RemapNone)
+	denied, _ := db.DeniedBoarding(6)
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := fmt.Sprintf("%s %v", errMessage(funcErr), denied)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
+
+// Negative Test 45: SearchTrips unknown company and plane
+func TestSearchTripsUnknownNegative(t *testing.T) {
+	dir := "tests/neg45/" // Директория с данными для теста
+	tbase, tmod, err := createTestDataBases() // Создаёт два временных файла и записываем в них
+	// тестовую базу данных
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	// Удаляем временные файлы
+	defer os.Remove(tbase)
+	defer os.Remove(tmod)
+
+	// Считываем тестовые данные
+	// eout - возвращаемые значения функции
+	// ediff - отличие базы данных после выполнения операции от стандартной
+	eout, ediff, err := readTest(dir)
+	if (err != nil) {
+		t.Error(err)
+		return
+	}
+	eout = strings.Trim(eout, "\n")
+
+	// Начало теста
+	db := AeroDB{}
+	err = db.OpenDB(tmod)
+	if (err != nil) {
+		t.Errorf("Cannot open database: %v", err.Error())
+		return
+	}
+	
+	// Тестовое действие
+	_, funcErr := db.SearchTrips(TripQuery{Company: "StudAirlines"})
+	_, iterErr := db.IterSearchTrips(TripQuery{From: "Moscow", Plane: "Boeing 737"})
+
+	err = db.CloseDB()
+	if (err != nil) {
+		t.Errorf("Cannot close database: %v", err.Error())
+		return
+	}
+
+	// Получение вывода в строковом формате
+	out := errMessage(funcErr) + " | " + errMessage(iterErr)
+	// Сравнение полученной базы данных исходной
+	diff, err := diffSql(tbase, tmod)
+	
+	if (err != nil) {
+		t.Errorf("Cannot compare databases: %v", err.Error())
+		return
+	}
+	// Анализ полученных результатов с ожидаемыми
+	// Сравниваем возвращаемые значения функции
+	if (out != eout) {
+		t.Errorf("Incorrect output\nGot:\n%v\nExpected:\n%v", out, eout)
+	}
+	// Сравниваем изменение базы данных
+	if (diff != ediff) {
+		t.Errorf("Incorrect database action\nGot:\n%v\nExpected:\n%v", diff, ediff)
+	}
+}
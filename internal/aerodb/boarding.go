@@ -0,0 +1,258 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const (
+	StatusBoarded TicketStatus = "boarded"
+	// Пассажир не явился на посадку до её закрытия, место билета освобождено
+	StatusNoShow TicketStatus = "no-show"
+)
+
+// Билеты, по которым ещё можно сесть в самолёт
+const notBoarded = "status IN ('" + string(StatusBooked) + "', '" + string(StatusCheckedIn) + "')"
+
+// Ход посадки: сколько пассажиров из проданных билетов уже на борту
+type BoardingStatus struct {
+	Seats     int
+	Tickets   int
+	CheckedIn int
+	Boarded   int
+}
+
+func (s BoardingStatus) String() string {
+	return fmt.Sprintf("%d of %d boarded (%d checked in, %d seats)", s.Boarded, s.Tickets, s.CheckedIn, s.Seats)
+}
+
+// Билет пассажира, не явившегося на посадку
+type NoShow struct {
+	Ticket      int
+	Trip        int
+	PassengerID int
+	Passenger   string
+}
+
+func (n NoShow) String() string {
+	return fmt.Sprintf("trip %d: ticket %d (%s)", n.Trip, n.Ticket, n.Passenger)
+}
+
+// Итог закрытия посадки: неявившиеся пассажиры, освобождённые места
+// и пассажиры из очереди ожидания, получившие эти места
+type BoardingReport struct {
+	NoShows  []NoShow
+	Released []int
+	Promoted []Promotion
+}
+
+// Отмечает посадку пассажира в самолёт по всем его билетам с местами.
+// Поездка должна быть в состоянии boarding.
+func (db *AeroDB) MarkBoarded(tripID int, passenger string) error {
+	return db.markBoarded(tripID, byName(passenger))
+}
+
+func (db *AeroDB) MarkBoardedByID(tripID int, passengerID int) error {
+	return db.markBoarded(tripID, byID(passengerID))
+}
+
+func (db *AeroDB) markBoarded(tripID int, passenger passengerRef) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		state, err := tripState(tx, tripID)
+		if err != nil {
+			return err
+		}
+		if state != StateBoarding {
+			return fmt.Errorf("%w: trip %d is %s", ErrTripState, tripID, state)
+		}
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.Exec(`UPDATE Taken SET status = ?, boarded_at = ?
+			WHERE trip_id = ? AND passenger_id = ? AND place IS NOT NULL AND `+notBoarded,
+			StatusBoarded, db.now(), tripID, passengerID)
+		if err != nil {
+			return dbError(err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return dbError(err)
+		} else if n > 0 {
+			return nil
+		}
+
+		// Объясняем, почему посадка невозможна
+		var status TicketStatus
+		err = tx.QueryRow(`SELECT status FROM Taken WHERE trip_id = ? AND passenger_id = ?
+			ORDER BY status = ? DESC, id LIMIT 1`, tripID, passengerID, StatusBoarded).Scan(&status)
+		switch {
+		case err == sql.ErrNoRows:
+			return fmt.Errorf("%w: %s has no ticket on trip %d", ErrNotFound, passenger, tripID)
+		case err != nil:
+			return dbError(err)
+		case status == StatusBoarded:
+			return ErrAlreadyIn
+		case status == StatusNoShow:
+			return fmt.Errorf("%w: %s is a no-show", ErrBoarding, passenger)
+		}
+		return fmt.Errorf("%w: %s has no seat", ErrBoarding, passenger)
+	})
+}
+
+// Ход посадки поездки
+func (db *AeroDB) BoardingCount(tripID int) (BoardingStatus, error) {
+	if db.db == nil {
+		return BoardingStatus{}, ErrNotOpened
+	}
+	seats, err := tripSeats(db.db, tripID)
+	if err != nil {
+		return BoardingStatus{}, err
+	}
+	s := BoardingStatus{Seats: seats}
+	err = db.db.QueryRow(`SELECT COUNT(*), COUNT(checkin_seq), IFNULL(SUM(status = ?), 0)
+		FROM Taken WHERE trip_id = ? AND status <> ?`, StatusBoarded, tripID, StatusNoShow).Scan(&s.Tickets, &s.CheckedIn, &s.Boarded)
+	if err != nil {
+		return BoardingStatus{}, dbError(err)
+	}
+	return s, nil
+}
+
+// Закрывает посадку: пассажиры, не севшие в самолёт, отмечаются неявившимися,
+// их места освобождаются и отдаются пассажирам из очереди ожидания.
+// Получившие места пассажиры могут сесть до следующего закрытия посадки.
+func (db *AeroDB) CloseBoarding(tripID int) (BoardingReport, error) {
+	report := BoardingReport{NoShows: []NoShow{}, Released: []int{}}
+	err := db.inTx(func(tx *sql.Tx) error {
+		state, err := tripState(tx, tripID)
+		if err != nil {
+			return err
+		}
+		if state != StateBoarding {
+			return fmt.Errorf("%w: trip %d is %s", ErrTripState, tripID, state)
+		}
+		report.NoShows, err = noShows(tx, "k.trip_id = ? AND k."+notBoarded, tripID)
+		if err != nil {
+			return err
+		}
+		report.Released, err = queryInts(tx, "SELECT place FROM Taken WHERE trip_id = ? AND place IS NOT NULL AND "+notBoarded+
+			" ORDER BY place", tripID)
+		if err != nil {
+			return dbError(err)
+		}
+		if report.Released == nil {
+			report.Released = []int{}
+		}
+		_, err = tx.Exec("UPDATE Taken SET status = ?, place = NULL WHERE trip_id = ? AND "+notBoarded, StatusNoShow, tripID)
+		if err != nil {
+			return dbError(err)
+		}
+
+		now := db.now()
+		for _, seat := range report.Released {
+			p, err := db.promoteWaitlist(tx, tripID, seat, now)
+			if err != nil {
+				return err
+			}
+			if p != nil {
+				report.Promoted = append(report.Promoted, *p)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return BoardingReport{}, err
+	}
+	db.notify(report.Promoted)
+	return report, nil
+}
+
+// Курсор по неявившимся пассажирам, как TripIterator
+type NoShowIterator struct {
+	cursor[NoShow]
+}
+
+func (it *NoShowIterator) NoShow() NoShow {
+	return it.cur
+}
+
+func scanNoShow(rows *sql.Rows) (NoShow, error) {
+	var n NoShow
+	err := rows.Scan(&n.Ticket, &n.Trip, &n.PassengerID, &n.Passenger)
+	return n, err
+}
+
+// Неявившиеся пассажиры по условию cond на билет k и поездку t
+func iterNoShows(q querier, cond string, args ...interface{}) (*NoShowIterator, error) {
+	c, err := openCursor(q, scanNoShow, `SELECT k.id, k.trip_id, k.passenger_id, IFNULL(p.name, '') FROM Taken k
+		JOIN Trip t ON t.id = k.trip_id
+		JOIN Passenger p ON p.id = k.passenger_id
+		WHERE `+cond+` ORDER BY julianday(t.time_out), k.trip_id, k.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &NoShowIterator{c}, nil
+}
+
+func noShows(q querier, cond string, args ...interface{}) ([]NoShow, error) {
+	it, err := iterNoShows(q, cond, args...)
+	if err != nil {
+		return nil, err
+	}
+	return it.all()
+}
+
+// Неявившиеся пассажиры поездки, пустой слайс - все явились или посадка не закрывалась
+func (db *AeroDB) GetNoShows(tripID int) ([]NoShow, error) {
+	it, err := db.IterNoShows(tripID)
+	if err != nil {
+		return nil, err
+	}
+	return it.all()
+}
+
+func (db *AeroDB) IterNoShows(tripID int) (*NoShowIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	if _, err := tripState(db.db, tripID); err != nil {
+		return nil, err
+	}
+	return iterNoShows(db.db, "k.trip_id = ? AND k.status = ?", tripID, StatusNoShow)
+}
+
+// Неявки пассажира по всем поездкам в порядке вылета
+func (db *AeroDB) GetPassengerNoShows(passenger string) ([]NoShow, error) {
+	return db.passengerNoShows(byName(passenger))
+}
+
+func (db *AeroDB) GetPassengerNoShowsByID(passengerID int) ([]NoShow, error) {
+	return db.passengerNoShows(byID(passengerID))
+}
+
+func (db *AeroDB) passengerNoShows(passenger passengerRef) ([]NoShow, error) {
+	it, err := db.iterPassengerNoShows(passenger)
+	if err != nil {
+		return nil, err
+	}
+	return it.all()
+}
+
+func (db *AeroDB) IterPassengerNoShows(passenger string) (*NoShowIterator, error) {
+	return db.iterPassengerNoShows(byName(passenger))
+}
+
+func (db *AeroDB) IterPassengerNoShowsByID(passengerID int) (*NoShowIterator, error) {
+	return db.iterPassengerNoShows(byID(passengerID))
+}
+
+func (db *AeroDB) iterPassengerNoShows(passenger passengerRef) (*NoShowIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	passengerID, err := passenger.resolve(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return iterNoShows(db.db, "k.passenger_id = ? AND k.status = ?", passengerID, StatusNoShow)
+}
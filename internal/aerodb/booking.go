@@ -0,0 +1,74 @@
+package aerodb
+
+import (
+	"fmt"
+)
+
+// Правила бронирования для пассажиров. По умолчанию (nil) не проверяются.
+type BookingRules struct {
+	// Не больше одного билета пассажира на поездку. Дополнительное
+	// место покупается явно методом TakeExtraSeat.
+	OneTicketPerTrip bool
+	// Пассажир не может иметь билеты на поездки, пересекающиеся по времени
+	NoOverlap bool
+}
+
+// У пассажира уже есть билет на поездку
+type DuplicateTicketError struct {
+	Passenger, Trip int
+}
+
+func (e *DuplicateTicketError) Error() string {
+	return fmt.Sprintf("%v: passenger %d on trip %d", ErrDuplicateTicket, e.Passenger, e.Trip)
+}
+
+func (e *DuplicateTicketError) Unwrap() error {
+	return ErrDuplicateTicket
+}
+
+// У пассажира есть билет на поездку Other, пересекающуюся по времени с поездкой Trip
+type PassengerBusyError struct {
+	Passenger, Trip, Other int
+}
+
+func (e *PassengerBusyError) Error() string {
+	return fmt.Sprintf("%v: passenger %d is on trip %d during trip %d", ErrPassengerBusy, e.Passenger, e.Other, e.Trip)
+}
+
+func (e *PassengerBusyError) Unwrap() error {
+	return ErrPassengerBusy
+}
+
+// Проверяет, что пассажиру можно продать ещё один билет на поездку.
+// extra - покупка дополнительного места, правило одного билета не проверяется.
+func (db *AeroDB) checkPassenger(q querier, tripID, passengerID int, extra bool) error {
+	r := db.Booking
+	if r == nil {
+		return nil
+	}
+	if r.OneTicketPerTrip && !extra {
+		in, err := exists(q, "SELECT 1 FROM Taken WHERE trip_id = ? AND passenger_id = ? AND status <> ?",
+			tripID, passengerID, StatusNoShow)
+		if err != nil {
+			return err
+		}
+		if in {
+			return &DuplicateTicketError{Passenger: passengerID, Trip: tripID}
+		}
+	}
+	if r.NoOverlap {
+		other, err := queryInts(q, `SELECT t.id FROM Taken k
+			JOIN Trip t ON t.id = k.trip_id
+			JOIN Trip n ON n.id = ?
+			WHERE k.passenger_id = ? AND k.status <> ? AND t.id <> n.id AND t.state <> ?
+				AND julianday(t.time_out) < julianday(n.time_in) AND julianday(n.time_out) < julianday(t.time_in)
+			ORDER BY t.time_out LIMIT 1`, tripID, passengerID, StatusNoShow, StateCancelled)
+		if err != nil {
+			return dbError(err)
+		}
+		if len(other) > 0 {
+			return &PassengerBusyError{Passenger: passengerID, Trip: tripID, Other: other[0]}
+		}
+	}
+	return nil
+}
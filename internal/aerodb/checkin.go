@@ -0,0 +1,309 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	StatusCheckedIn TicketStatus = "checked-in"
+)
+
+// Окно регистрации: открывается за Opens и закрывается за Closes до вылета
+type CheckInWindow struct {
+	Opens, Closes time.Duration
+}
+
+var defaultCheckInWindow = CheckInWindow{Opens: 24 * time.Hour, Closes: 40 * time.Minute}
+
+func (db *AeroDB) checkInWindow() CheckInWindow {
+	if db.CheckInWindow != nil {
+		return *db.CheckInWindow
+	}
+	return defaultCheckInWindow
+}
+
+// Посадочный талон зарегистрированного билета
+type BoardingPass struct {
+	Ticket      int
+	Trip        int
+	Passenger   PassengerInfo
+	Company     string
+	Flight      string
+	TownOut     string
+	TownIn      string
+	TimeOut     time.Time
+	Seat        int
+	Label       string
+	Cabin       Cabin
+	Gate        string
+	Group       int
+	Sequence    int
+	CheckedInAt time.Time
+}
+
+func (b BoardingPass) String() string {
+	return fmt.Sprintf("%s %s -> %s, seat %s, group %d, seq %d", b.Flight, b.TownOut, b.TownIn, b.Label, b.Group, b.Sequence)
+}
+
+// Талон для печати
+func (b BoardingPass) Text() string {
+	gate := b.Gate
+	if gate == "" {
+		gate = "-"
+	}
+	var s strings.Builder
+	fmt.Fprintf(&s, "BOARDING PASS  %s\n", b.Company)
+	fmt.Fprintf(&s, "Passenger: %s\n", b.Passenger.Name)
+	fmt.Fprintf(&s, "Flight:    %s\n", b.Flight)
+	fmt.Fprintf(&s, "From:      %s\n", b.TownOut)
+	fmt.Fprintf(&s, "To:        %s\n", b.TownIn)
+	fmt.Fprintf(&s, "Departure: %s\n", b.TimeOut.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&s, "Seat:      %s (%s)\n", b.Label, b.Cabin)
+	fmt.Fprintf(&s, "Gate:      %s\n", gate)
+	fmt.Fprintf(&s, "Group:     %d\n", b.Group)
+	fmt.Fprintf(&s, "Sequence:  %d\n", b.Sequence)
+	return s.String()
+}
+
+// Строка для штрихкода в духе IATA BCBP (обязательные поля, одна поездка).
+// Кодов аэропортов и авиакомпаний в базе нет, вместо них берутся
+// первые буквы названий городов и компании.
+func (b BoardingPass) BCBP() string {
+	name := b.Passenger.Name
+	if b.Passenger.FamilyName != "" {
+		name = b.Passenger.FamilyName + "/" + b.Passenger.GivenName
+	}
+	row, letter := 0, ""
+	if b.Label != "" {
+		fmt.Sscanf(b.Label, "%d%s", &row, &letter)
+	}
+	return "M1" +
+		bcbpField(name, 20) +
+		"E" +
+		bcbpField(fmt.Sprint(b.Ticket), 7) +
+		bcbpField(code(b.TownOut, 3), 3) +
+		bcbpField(code(b.TownIn, 3), 3) +
+		bcbpField(code(b.Company, 2), 3) +
+		fmt.Sprintf("%04d ", b.Trip%10000) +
+		fmt.Sprintf("%03d", b.TimeOut.YearDay()) +
+		cabinCode(b.Cabin) +
+		fmt.Sprintf("%03d%s", row%1000, bcbpField(letter, 1)) +
+		fmt.Sprintf("%04d ", b.Sequence%10000) +
+		"1" +
+		"00"
+}
+
+// Поле фиксированной длины: заглавные буквы, дополняется пробелами справа
+func bcbpField(s string, n int) string {
+	r := []rune(strings.ToUpper(s))
+	if len(r) > n {
+		r = r[:n]
+	}
+	return string(r) + strings.Repeat(" ", n-len(r))
+}
+
+// Первые n букв и цифр названия
+func code(name string, n int) string {
+	var res []rune
+	for _, r := range strings.ToUpper(name) {
+		if len(res) < n && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			res = append(res, r)
+		}
+	}
+	return string(res)
+}
+
+func cabinCode(c Cabin) string {
+	switch c {
+	case CabinFirst:
+		return "F"
+	case CabinBusiness:
+		return "J"
+	case CabinPremium:
+		return "W"
+	}
+	return "Y"
+}
+
+// Группа посадки по классу места: первый класс садится первым
+func boardingGroup(c Cabin) int {
+	switch c {
+	case CabinFirst:
+		return 1
+	case CabinBusiness:
+		return 2
+	case CabinPremium:
+		return 3
+	}
+	return 4
+}
+
+// Задаёт выход на посадку поездки
+func (db *AeroDB) SetGate(tripID int, gate string) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		if _, err := tripState(tx, tripID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE Trip SET gate = ? WHERE id = ?", strings.TrimSpace(gate), tripID); err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+// Регистрирует пассажира на поездку и возвращает посадочный талон.
+// Пассажир должен иметь в поездке ровно один билет, иначе используется CheckInTicket.
+func (db *AeroDB) CheckIn(tripID int, passenger string) (BoardingPass, error) {
+	return db.checkInPassenger(tripID, byName(passenger))
+}
+
+func (db *AeroDB) CheckInByID(tripID int, passengerID int) (BoardingPass, error) {
+	return db.checkInPassenger(tripID, byID(passengerID))
+}
+
+func (db *AeroDB) checkInPassenger(tripID int, passenger passengerRef) (BoardingPass, error) {
+	var pass BoardingPass
+	err := db.inTx(func(tx *sql.Tx) error {
+		if _, err := tripState(tx, tripID); err != nil {
+			return err
+		}
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+		tickets, err := queryInts(tx, "SELECT id FROM Taken WHERE trip_id = ? AND passenger_id = ?", tripID, passengerID)
+		if err != nil {
+			return dbError(err)
+		}
+		switch len(tickets) {
+		case 0:
+			return fmt.Errorf("%w: %s has no ticket on trip %d", ErrNotFound, passenger, tripID)
+		case 1:
+		default:
+			return fmt.Errorf("%w: %s holds %d tickets on trip %d", ErrManySeats, passenger, len(tickets), tripID)
+		}
+		pass, err = db.checkIn(tx, tickets[0])
+		return err
+	})
+	if err != nil {
+		return BoardingPass{}, err
+	}
+	return pass, nil
+}
+
+// Регистрирует билет по его номеру (Taken.id)
+func (db *AeroDB) CheckInTicket(ticket int) (BoardingPass, error) {
+	var pass BoardingPass
+	err := db.inTx(func(tx *sql.Tx) (err error) {
+		pass, err = db.checkIn(tx, ticket)
+		return err
+	})
+	if err != nil {
+		return BoardingPass{}, err
+	}
+	return pass, nil
+}
+
+// Регистрация возможна только для запланированной поездки в окне регистрации.
+// Билету без места назначается первое свободное место.
+func (db *AeroDB) checkIn(tx *sql.Tx, ticket int) (BoardingPass, error) {
+	var tripID int
+	var place sql.NullInt64
+	var status TicketStatus
+	var timeOut time.Time
+	var state TripState
+	err := tx.QueryRow(`SELECT k.trip_id, k.place, k.status, t.time_out, t.state
+		FROM Taken k JOIN Trip t ON t.id = k.trip_id
+		WHERE k.id = ?`, ticket).Scan(&tripID, &place, &status, &timeOut, &state)
+	if err == sql.ErrNoRows {
+		return BoardingPass{}, ErrNotFound
+	}
+	if err != nil {
+		return BoardingPass{}, dbError(err)
+	}
+	if state != StateScheduled {
+		return BoardingPass{}, fmt.Errorf("%w: trip %d is %s", ErrTripState, tripID, state)
+	}
+	if status != StatusBooked {
+		return BoardingPass{}, fmt.Errorf("%w: ticket %d is %s", ErrAlreadyIn, ticket, status)
+	}
+	now := db.now()
+	w := db.checkInWindow()
+	if opens := timeOut.Add(-w.Opens); now.Before(opens) {
+		return BoardingPass{}, fmt.Errorf("%w: opens at %s", ErrCheckInClosed, opens.Format(time.RFC3339))
+	}
+	if closes := timeOut.Add(-w.Closes); !now.Before(closes) {
+		return BoardingPass{}, fmt.Errorf("%w: closed at %s", ErrCheckInClosed, closes.Format(time.RFC3339))
+	}
+
+	seat := int(place.Int64)
+	if !place.Valid {
+		free, err := freeSeats(tx, tripID, now)
+		if err != nil {
+			return BoardingPass{}, err
+		}
+		if len(free) == 0 {
+			return BoardingPass{}, fmt.Errorf("%w: no free seat for ticket %d", ErrOverbooked, ticket)
+		}
+		seat = free[0]
+	}
+	_, err = tx.Exec(`UPDATE Taken SET place = ?, status = ?, checked_in_at = ?,
+		checkin_seq = (SELECT IFNULL(MAX(checkin_seq), 0) + 1 FROM Taken WHERE trip_id = ?)
+		WHERE id = ?`, seat, StatusCheckedIn, now, tripID, ticket)
+	if err != nil {
+		return BoardingPass{}, dbError(err)
+	}
+	return boardingPass(tx, ticket)
+}
+
+// Посадочный талон зарегистрированного билета
+func (db *AeroDB) GetBoardingPass(ticket int) (BoardingPass, error) {
+	if db.db == nil {
+		return BoardingPass{}, ErrNotOpened
+	}
+	return boardingPass(db.db, ticket)
+}
+
+func boardingPass(q querier, ticket int) (BoardingPass, error) {
+	var b BoardingPass
+	var passengerID int
+	var seq sql.NullInt64
+	var checkedIn sql.NullTime
+	err := q.QueryRow(`SELECT k.id, k.trip_id, k.passenger_id, IFNULL(c.name, ''), t.town_out, t.town_in, t.time_out,
+		IFNULL(k.place, 0), t.gate, k.checkin_seq, k.checked_in_at
+		FROM Taken k
+		JOIN Trip t ON t.id = k.trip_id
+		LEFT JOIN Company c ON c.id = t.company_id
+		WHERE k.id = ?`, ticket).Scan(&b.Ticket, &b.Trip, &passengerID, &b.Company, &b.TownOut, &b.TownIn, &b.TimeOut,
+		&b.Seat, &b.Gate, &seq, &checkedIn)
+	if err == sql.ErrNoRows {
+		return BoardingPass{}, ErrNotFound
+	}
+	if err != nil {
+		return BoardingPass{}, dbError(err)
+	}
+	if !seq.Valid {
+		return BoardingPass{}, fmt.Errorf("%w: ticket %d is not checked in", ErrNotFound, ticket)
+	}
+	b.Sequence, b.CheckedInAt = int(seq.Int64), checkedIn.Time
+
+	row := q.QueryRow("SELECT "+passengerColumns+" FROM Passenger WHERE id = ?", passengerID)
+	if b.Passenger, err = scanPassenger(row); err != nil {
+		return BoardingPass{}, err
+	}
+	seatMap, err := tripSeatMap(q, b.Trip)
+	if err != nil {
+		return BoardingPass{}, err
+	}
+	b.Cabin = CabinEconomy
+	if b.Seat > 0 && b.Seat <= len(seatMap) {
+		b.Label, b.Cabin = seatMap[b.Seat-1].Label(), seatMap[b.Seat-1].Cabin
+	}
+	b.Group = boardingGroup(b.Cabin)
+	b.Flight = fmt.Sprintf("%s%04d", code(b.Company, 2), b.Trip)
+	return b, nil
+}
@@ -0,0 +1,146 @@
+package aerodb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+func (db *AeroDB) AddCompany(name string) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		in, err := exists(tx, "SELECT 1 FROM Company WHERE name = ?", name)
+		if err != nil {
+			return err
+		}
+		if in {
+			return ErrAlreadyIn
+		}
+		if _, err = tx.Exec("INSERT INTO Company(name) VALUES (?)", name); err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+// Удаляет компанию. Самолёты и поездки компании передаются наследнику inherit,
+// если наследник не указан, то удаляются вместе с занятыми местами.
+func (db *AeroDB) DelCompany(name, inherit string) error {
+	_, err := db.DelCompanyReport(name, inherit, DelCompanyOptions{})
+	return err
+}
+
+type DelCompanyOptions struct {
+	// Только подготовить отчёт, не изменяя базу
+	DryRun bool
+}
+
+// Что при удалении компании передано наследнику Heir (или удалено, если Heir пустой):
+// самолёты компании, её поездки с вылетом позже и раньше текущего времени
+// и количество билетов на эти поездки
+type CompanyTransfer struct {
+	Company       string
+	Heir          string
+	Planes        []string
+	UpcomingTrips []int
+	PastTrips     []int
+	Tickets       int
+	DryRun        bool
+}
+
+func (r CompanyTransfer) String() string {
+	action := "deleted"
+	if r.Heir != "" {
+		action = "-> " + r.Heir
+	}
+	if r.DryRun {
+		action += " (dry run)"
+	}
+	return fmt.Sprintf("%s %s: planes %v, upcoming trips %v, past trips %v, %d tickets",
+		r.Company, action, r.Planes, r.UpcomingTrips, r.PastTrips, r.Tickets)
+}
+
+// Откатывает транзакцию пробного запуска
+var errDryRun = errors.New("dry run")
+
+// Удаляет компанию так же, как DelCompany, и возвращает отчёт о переданных
+// или удалённых самолётах, поездках и билетах. Всё выполняется в одной транзакции;
+// при DryRun отчёт готовится так же, но изменения откатываются.
+func (db *AeroDB) DelCompanyReport(name, inherit string, opts DelCompanyOptions) (CompanyTransfer, error) {
+	report := CompanyTransfer{Company: name, Heir: inherit, DryRun: opts.DryRun}
+	err := db.inTx(func(tx *sql.Tx) error {
+		companyID, err := idByName(tx, "Company", name)
+		if err != nil {
+			return err
+		}
+		heirID := 0
+		if inherit != "" {
+			if inherit == name {
+				return ErrNotFound
+			}
+			if heirID, err = idByName(tx, "Company", inherit); err != nil {
+				return err
+			}
+		}
+
+		// Наследнику переходят поездки компании, самолёты переходят вместе со своими
+		// поездками других компаний. Без наследника удаляются и те, и другие.
+		trips := "company_id = ?"
+		args := []interface{}{companyID}
+		if heirID == 0 {
+			trips += " OR plane_id IN (SELECT id FROM Plane WHERE company_id = ?)"
+			args = append(args, companyID)
+		}
+		if report.Planes, err = queryStrings(tx, "SELECT name FROM Plane WHERE company_id = ? ORDER BY id", companyID); err != nil {
+			return dbError(err)
+		}
+		now := db.now()
+		report.UpcomingTrips, err = queryInts(tx, "SELECT id FROM Trip WHERE ("+trips+") AND julianday(time_out) > julianday(?) ORDER BY id",
+			append(args, now)...)
+		if err != nil {
+			return dbError(err)
+		}
+		report.PastTrips, err = queryInts(tx, "SELECT id FROM Trip WHERE ("+trips+") AND julianday(time_out) <= julianday(?) ORDER BY id",
+			append(args, now)...)
+		if err != nil {
+			return dbError(err)
+		}
+		err = tx.QueryRow("SELECT COUNT(*) FROM Taken WHERE trip_id IN (SELECT id FROM Trip WHERE "+trips+")", args...).Scan(&report.Tickets)
+		if err != nil {
+			return dbError(err)
+		}
+
+		if heirID == 0 {
+			stmts := []string{
+				"DELETE FROM Taken WHERE trip_id IN (SELECT id FROM Trip WHERE " + trips + ")",
+				"DELETE FROM Trip WHERE " + trips,
+			}
+			for _, stmt := range stmts {
+				if _, err = tx.Exec(stmt, args...); err != nil {
+					return dbError(err)
+				}
+			}
+			if _, err = tx.Exec("DELETE FROM Plane WHERE company_id = ?", companyID); err != nil {
+				return dbError(err)
+			}
+		} else {
+			if _, err = tx.Exec("UPDATE Plane SET company_id = ? WHERE company_id = ?", heirID, companyID); err != nil {
+				return dbError(err)
+			}
+			if _, err = tx.Exec("UPDATE Trip SET company_id = ? WHERE company_id = ?", heirID, companyID); err != nil {
+				return dbError(err)
+			}
+		}
+
+		if _, err = tx.Exec("DELETE FROM Company WHERE id = ?", companyID); err != nil {
+			return dbError(err)
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		return CompanyTransfer{}, err
+	}
+	return report, nil
+}
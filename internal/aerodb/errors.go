@@ -15,6 +15,28 @@ var (
     ErrFile         = errors.New("cannot open the file")
     ErrDBFormat     = errors.New("not correct format of database")
     ErrIncorectTime = errors.New("incorrect time period")
+    ErrSchemaVersion = errors.New("database schema is newer than supported")
+    ErrIncorrectTrip = errors.New("incorrect trip data")
+    ErrTripState     = errors.New("incorrect trip state")
+    ErrPlaneBusy     = errors.New("plane is busy")
+    ErrScheduleRule  = errors.New("schedule rule violated")
+    ErrManySeats     = errors.New("passenger holds several seats")
+    ErrHoldExpired   = errors.New("seat hold expired")
+    ErrGroupBooking  = errors.New("group booking failed")
+    ErrNoAdjacentSeats = errors.New("no adjacent free seats")
+    ErrSeatLayout    = errors.New("incorrect seat layout")
+    ErrNotFull       = errors.New("trip has free seats")
+    ErrWaitlistPosition = errors.New("incorrect waitlist position")
+    ErrOverbooked    = errors.New("ticket limit reached")
+    ErrFare          = errors.New("incorrect fare")
+    ErrAmbiguous     = errors.New("several elements match")
+    ErrPassenger     = errors.New("incorrect passenger data")
+    ErrDuplicateTicket = errors.New("passenger already has a ticket for the trip")
+    ErrPassengerBusy = errors.New("passenger is on another trip at this time")
+    ErrCheckInClosed = errors.New("check-in is closed")
+    ErrBoarding      = errors.New("passenger cannot board")
+    ErrSeatConflict  = errors.New("seats do not fit the plane")
+    ErrHeirCompany   = errors.New("heir belongs to another company")
     
     // На случай неконтролируемых ошибок работы с базой данных
     ErrDB           = errors.New("unknown mistakes with database")
@@ -0,0 +1,318 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Денежная сумма в минимальных единицах валюты (копейках)
+type Money int64
+
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, m/100, m%100)
+}
+
+// Разбирает сумму вида "1234.50" без потери точности
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "-")
+	whole, frac, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if whole == "" || len(frac) > 2 || strings.ContainsAny(whole+frac, "+-") {
+		return 0, fmt.Errorf("%w: bad amount %q", ErrFare, s)
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	n, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad amount %q", ErrFare, s)
+	}
+	if neg {
+		n = -n
+	}
+	return Money(n), nil
+}
+
+// Цена билета при загрузке поездки от LoadFactor процентов
+type PriceTier struct {
+	LoadFactor int
+	Price      Money
+}
+
+// Тариф класса: базовая цена и цены при высокой загрузке поездки
+type CabinFare struct {
+	Cabin Cabin
+	Base  Money
+	Tiers []PriceTier
+}
+
+// Цена при загрузке load (в процентах проданных билетов от числа мест)
+func (f CabinFare) price(load int) Money {
+	price := f.Base
+	for _, t := range f.Tiers {
+		if load >= t.LoadFactor {
+			price = t.Price
+		}
+	}
+	return price
+}
+
+// Задаёт тарифы поездки, заменяя прежние. Цены уже проданных билетов не меняются.
+func (db *AeroDB) SetFares(tripID int, fares []CabinFare) error {
+	fares = append([]CabinFare(nil), fares...)
+	seen := make(map[Cabin]bool)
+	for i, f := range fares {
+		if !f.Cabin.Valid() {
+			return fmt.Errorf("%w: unknown cabin %q", ErrFare, f.Cabin)
+		}
+		if seen[f.Cabin] {
+			return fmt.Errorf("%w: %s fare repeats", ErrFare, f.Cabin)
+		}
+		seen[f.Cabin] = true
+		if f.Base < 0 {
+			return fmt.Errorf("%w: %s base fare %v", ErrFare, f.Cabin, f.Base)
+		}
+		tiers := append([]PriceTier(nil), f.Tiers...)
+		sort.Slice(tiers, func(i, j int) bool { return tiers[i].LoadFactor < tiers[j].LoadFactor })
+		for j, t := range tiers {
+			if t.LoadFactor <= 0 || t.Price < 0 || (j > 0 && t.LoadFactor == tiers[j-1].LoadFactor) {
+				return fmt.Errorf("%w: %s tier %d%%: %v", ErrFare, f.Cabin, t.LoadFactor, t.Price)
+			}
+		}
+		fares[i].Tiers = tiers
+	}
+
+	return db.inTx(func(tx *sql.Tx) error {
+		if _, err := tripState(tx, tripID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec("DELETE FROM Fare WHERE trip_id = ?", tripID); err != nil {
+			return dbError(err)
+		}
+		for _, f := range fares {
+			res, err := tx.Exec("INSERT INTO Fare(trip_id, cabin, base) VALUES (?, ?, ?)", tripID, f.Cabin, f.Base)
+			if err != nil {
+				return dbError(err)
+			}
+			fareID, err := res.LastInsertId()
+			if err != nil {
+				return dbError(err)
+			}
+			for _, t := range f.Tiers {
+				_, err = tx.Exec("INSERT INTO FareTier(fare_id, load_factor, price) VALUES (?, ?, ?)", fareID, t.LoadFactor, t.Price)
+				if err != nil {
+					return dbError(err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Тарифы поездки по классам, пустой слайс - тарифы не заданы
+func (db *AeroDB) GetFares(tripID int) ([]CabinFare, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	if _, err := tripState(db.db, tripID); err != nil {
+		return nil, err
+	}
+	fares, err := tripFares(db.db, tripID)
+	if err != nil {
+		return nil, err
+	}
+	res := []CabinFare{}
+	for _, c := range []Cabin{CabinFirst, CabinBusiness, CabinPremium, CabinEconomy} {
+		if f, ok := fares[c]; ok {
+			res = append(res, f)
+		}
+	}
+	return res, nil
+}
+
+func tripFares(q querier, tripID int) (map[Cabin]CabinFare, error) {
+	rows, err := q.Query(`SELECT f.cabin, f.base, t.load_factor, t.price FROM Fare f
+		LEFT JOIN FareTier t ON t.fare_id = f.id
+		WHERE f.trip_id = ? ORDER BY f.id, t.load_factor`, tripID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer rows.Close()
+
+	res := make(map[Cabin]CabinFare)
+	for rows.Next() {
+		var cabin Cabin
+		var base Money
+		var load, price sql.NullInt64
+		if err = rows.Scan(&cabin, &base, &load, &price); err != nil {
+			return nil, dbError(err)
+		}
+		f := res[cabin]
+		f.Cabin, f.Base = cabin, base
+		if load.Valid {
+			f.Tiers = append(f.Tiers, PriceTier{LoadFactor: int(load.Int64), Price: Money(price.Int64)})
+		}
+		res[cabin] = f
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dbError(err)
+	}
+	return res, nil
+}
+
+// Текущая цена места поездки, seat = 0 - билет без места (по тарифу эконом-класса).
+// ok = false, если для класса места тариф не задан.
+func seatPrice(q querier, tripID, seat int) (price Money, ok bool, err error) {
+	fares, err := tripFares(q, tripID)
+	if err != nil || len(fares) == 0 {
+		return 0, false, err
+	}
+	cabin := CabinEconomy
+	if seat > 0 {
+		seatMap, err := tripSeatMap(q, tripID)
+		if err != nil {
+			return 0, false, err
+		}
+		if seat <= len(seatMap) {
+			cabin = seatMap[seat-1].Cabin
+		}
+	}
+	fare, ok := fares[cabin]
+	if !ok {
+		return 0, false, nil
+	}
+
+	var seats, sold int
+	err = q.QueryRow(`SELECT p.seats, (SELECT COUNT(*) FROM Taken WHERE trip_id = t.id AND status <> ?)
+		FROM Trip t JOIN Plane p ON p.id = t.plane_id WHERE t.id = ?`, StatusNoShow, tripID).Scan(&seats, &sold)
+	if err != nil {
+		return 0, false, dbError(err)
+	}
+	load := 0
+	if seats > 0 {
+		load = sold * 100 / seats
+	}
+	return fare.price(load), true, nil
+}
+
+// Текущая цена места поездки
+func (db *AeroDB) SeatPrice(tripID, seat int) (Money, error) {
+	if db.db == nil {
+		return 0, ErrNotOpened
+	}
+	seats, err := tripSeats(db.db, tripID)
+	if err != nil {
+		return 0, err
+	}
+	if seat < 1 || seat > seats {
+		return 0, ErrSeatRange
+	}
+	price, ok, err := seatPrice(db.db, tripID, seat)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: no fare for seat %d", ErrNotFound, seat)
+	}
+	return price, nil
+}
+
+// Продаёт билет на место seat (0 - без места) по текущей цене в момент now.
+// Если тариф не задан, цена билета не записывается.
+func insertTicket(tx *sql.Tx, tripID, passengerID, seat int, now time.Time) error {
+	price, ok, err := seatPrice(tx, tripID, seat)
+	if err != nil {
+		return err
+	}
+	var place, amount interface{}
+	if seat > 0 {
+		place = seat
+	}
+	if ok {
+		amount = price
+	}
+	_, err = tx.Exec("INSERT INTO Taken(trip_id, passenger_id, place, price, booked_at) VALUES (?, ?, ?, ?, ?)",
+		tripID, passengerID, place, amount, now)
+	if err != nil {
+		return dbError(err)
+	}
+	return nil
+}
+
+// Выручка: количество билетов, из них без записанной цены, и сумма цен
+type Revenue struct {
+	Tickets  int
+	Unpriced int
+	Total    Money
+}
+
+func (r Revenue) String() string {
+	return fmt.Sprintf("%v (%d tickets, %d unpriced)", r.Total, r.Tickets, r.Unpriced)
+}
+
+// Выручка по билетам неотменённых поездок, удовлетворяющих условию cond
+func revenue(q querier, cond string, args ...interface{}) (Revenue, error) {
+	var r Revenue
+	err := q.QueryRow(`SELECT COUNT(*), COUNT(*) - COUNT(k.price), IFNULL(SUM(k.price), 0)
+		FROM Taken k JOIN Trip t ON t.id = k.trip_id
+		WHERE t.state <> ? AND `+cond, append([]interface{}{StateCancelled}, args...)...).Scan(&r.Tickets, &r.Unpriced, &r.Total)
+	if err != nil {
+		return Revenue{}, dbError(err)
+	}
+	return r, nil
+}
+
+// Условие на время вылета поездки в промежутке [from, to], нулевое время - без ограничения
+func departedBetween(from, to time.Time) (string, []interface{}) {
+	cond := "1"
+	var args []interface{}
+	if !from.IsZero() {
+		cond += " AND julianday(t.time_out) >= julianday(?)"
+		args = append(args, from)
+	}
+	if !to.IsZero() {
+		cond += " AND julianday(t.time_out) <= julianday(?)"
+		args = append(args, to)
+	}
+	return cond, args
+}
+
+func (db *AeroDB) TripRevenue(tripID int) (Revenue, error) {
+	if db.db == nil {
+		return Revenue{}, ErrNotOpened
+	}
+	if _, err := tripState(db.db, tripID); err != nil {
+		return Revenue{}, err
+	}
+	return revenue(db.db, "t.id = ?", tripID)
+}
+
+// Выручка компании по поездкам с вылетом в промежутке [from, to]
+func (db *AeroDB) CompanyRevenue(company string, from, to time.Time) (Revenue, error) {
+	if db.db == nil {
+		return Revenue{}, ErrNotOpened
+	}
+	companyID, err := idByName(db.db, "Company", company)
+	if err != nil {
+		return Revenue{}, err
+	}
+	cond, args := departedBetween(from, to)
+	return revenue(db.db, "t.company_id = ? AND "+cond, append([]interface{}{companyID}, args...)...)
+}
+
+// Выручка по поездкам из townOut в townIn с вылетом в промежутке [from, to]
+func (db *AeroDB) RouteRevenue(townOut, townIn string, from, to time.Time) (Revenue, error) {
+	if db.db == nil {
+		return Revenue{}, ErrNotOpened
+	}
+	cond, args := departedBetween(from, to)
+	return revenue(db.db, "t.town_out = ? AND t.town_in = ? AND "+cond, append([]interface{}{townOut, townIn}, args...)...)
+}
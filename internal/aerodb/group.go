@@ -0,0 +1,194 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Место для пассажира группы: номер или обозначение вида "12C".
+// Если не задано ни то, ни другое, место подбирается автоматически.
+// Пассажир задаётся по PassengerID, а если он 0 - по имени Passenger.
+type GroupSeat struct {
+	Passenger   string
+	PassengerID int
+	Seat        int
+	Label       string
+}
+
+func (g GroupSeat) passenger() passengerRef {
+	if g.PassengerID != 0 {
+		return byID(g.PassengerID)
+	}
+	return byName(g.Passenger)
+}
+
+// Место группы, которое не удалось занять, и причина
+type SeatFailure struct {
+	Passenger string
+	Seat      int
+	Label     string
+	Err       error
+}
+
+func (f SeatFailure) String() string {
+	if f.Label != "" {
+		return fmt.Sprintf("seat %s (%s): %v", f.Label, f.Passenger, f.Err)
+	}
+	if f.Seat == 0 {
+		return fmt.Sprintf("auto seat (%s): %v", f.Passenger, f.Err)
+	}
+	return fmt.Sprintf("seat %d (%s): %v", f.Seat, f.Passenger, f.Err)
+}
+
+type GroupError struct {
+	Failures []SeatFailure
+}
+
+func (e *GroupError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		parts[i] = f.String()
+	}
+	return ErrGroupBooking.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// errors.Is находит как ErrGroupBooking, так и причины отдельных отказов
+func (e *GroupError) Unwrap() []error {
+	res := []error{ErrGroupBooking}
+	for _, f := range e.Failures {
+		res = append(res, f.Err)
+	}
+	return res
+}
+
+// Первые n подряд идущих номеров среди свободных мест (free по возрастанию)
+// в одном ряду одного класса
+func adjacentSeats(free []int, n int, seatMap []SeatInfo) []int {
+	together := func(a, b int) bool {
+		return b == a+1 && b <= len(seatMap) &&
+			seatMap[a-1].Row == seatMap[b-1].Row && seatMap[a-1].Cabin == seatMap[b-1].Cabin
+	}
+	start := 0
+	for i := range free {
+		if i > 0 && !together(free[i-1], free[i]) {
+			start = i
+		}
+		if i-start+1 == n {
+			return free[start : i+1]
+		}
+	}
+	return nil
+}
+
+// Занимает места для группы пассажиров: либо все, либо ни одного.
+// Места без номера и обозначения подбираются рядом друг с другом
+// в одном ряду среди свободных.
+// Возвращает группу с назначенными местами.
+func (db *AeroDB) BookGroup(tripID int, group []GroupSeat) ([]GroupSeat, error) {
+	res := make([]GroupSeat, len(group))
+	copy(res, group)
+
+	err := db.inTx(func(tx *sql.Tx) error {
+		seats, err := tripSeats(tx, tripID)
+		if err != nil {
+			return err
+		}
+		if err = checkBookable(tx, tripID); err != nil {
+			return err
+		}
+		if len(group) == 0 {
+			return ErrEmpty
+		}
+
+		now := db.now()
+		var failures []SeatFailure
+		fail := func(g GroupSeat, err error) {
+			failures = append(failures, SeatFailure{Passenger: g.passenger().String(), Seat: g.Seat, Label: g.Label, Err: err})
+		}
+		seatMap, err := tripSeatMap(tx, tripID)
+		if err != nil {
+			return err
+		}
+
+		passengers := make([]int, len(res))
+		requested := make(map[int]bool)
+		booked := make(map[int]bool)
+		var auto []int
+		for i, g := range res {
+			if passengers[i], err = g.passenger().resolve(tx); err != nil {
+				fail(g, err)
+				continue
+			}
+			if err = db.checkPassenger(tx, tripID, passengers[i], false); err != nil {
+				fail(g, err)
+				continue
+			}
+			if db.Booking != nil && db.Booking.OneTicketPerTrip && booked[passengers[i]] {
+				fail(g, &DuplicateTicketError{Passenger: passengers[i], Trip: tripID})
+				continue
+			}
+			booked[passengers[i]] = true
+			if g.Seat == 0 && g.Label != "" {
+				if res[i].Seat, err = seatNumber(tx, tripID, g.Label); err != nil {
+					fail(g, err)
+					continue
+				}
+				g = res[i]
+			}
+			switch {
+			case g.Seat == 0:
+				auto = append(auto, i)
+				continue
+			case g.Seat < 0 || g.Seat > seats:
+				fail(g, ErrSeatRange)
+				continue
+			case requested[g.Seat]:
+				fail(g, fmt.Errorf("%w: seat %d requested twice", ErrAlreadyTaken, g.Seat))
+				continue
+			}
+			requested[g.Seat] = true
+			if err = checkSeatFree(tx, tripID, g.Seat, now); err != nil {
+				fail(g, err)
+			}
+		}
+
+		if len(auto) > 0 {
+			free, err := freeSeats(tx, tripID, now)
+			if err != nil {
+				return err
+			}
+			var candidates []int
+			for _, seat := range free {
+				if !requested[seat] {
+					candidates = append(candidates, seat)
+				}
+			}
+			block := adjacentSeats(candidates, len(auto), seatMap)
+			for j, i := range auto {
+				if block == nil {
+					fail(res[i], fmt.Errorf("%w: need %d", ErrNoAdjacentSeats, len(auto)))
+				} else {
+					res[i].Seat = block[j]
+				}
+			}
+		}
+
+		if len(failures) > 0 {
+			return &GroupError{Failures: failures}
+		}
+		if err = db.checkTickets(tx, tripID, len(res)); err != nil {
+			return err
+		}
+		for i, g := range res {
+			if err = insertTicket(tx, tripID, passengers[i], g.Seat, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,130 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Поездка пассажира и его места в ней по возрастанию, 0 - билет без места
+type PassengerTrip struct {
+	Trip  Trip
+	Seats []int
+}
+
+func (p PassengerTrip) String() string {
+	return fmt.Sprintf("trip %d at %s, seats %v", p.Trip.id, p.Trip.timeOut.Format(time.DateTime), p.Seats)
+}
+
+// Поездки пассажира по времени вылета: предстоящие и уже вылетевшие
+type PassengerTrips struct {
+	Upcoming []PassengerTrip
+	Past     []PassengerTrip
+}
+
+// Условия выбора поездок пассажира. Нулевое время и пустая компания не ограничивают выбор.
+type PassengerTripFilter struct {
+	DepartAfter, DepartBefore time.Time
+	Company                   string
+}
+
+// Поездки, на которые у пассажира есть билеты, с вылетом в промежутке
+// [DepartAfter, DepartBefore]. Отменённые поездки тоже возвращаются.
+func (db *AeroDB) GetPassengerTrips(passenger string, f PassengerTripFilter) (PassengerTrips, error) {
+	return db.passengerTrips(byName(passenger), f)
+}
+
+func (db *AeroDB) GetPassengerTripsByID(passengerID int, f PassengerTripFilter) (PassengerTrips, error) {
+	return db.passengerTrips(byID(passengerID), f)
+}
+
+func (db *AeroDB) passengerTrips(passenger passengerRef, f PassengerTripFilter) (PassengerTrips, error) {
+	it, err := db.iterPassengerTrips(passenger, f)
+	if err != nil {
+		return PassengerTrips{}, err
+	}
+	trips, err := it.all()
+	if err != nil {
+		return PassengerTrips{}, err
+	}
+
+	now := db.now()
+	res := PassengerTrips{Upcoming: []PassengerTrip{}, Past: []PassengerTrip{}}
+	for _, pt := range trips {
+		if pt.Trip.timeOut.After(now) {
+			res.Upcoming = append(res.Upcoming, pt)
+		} else {
+			res.Past = append(res.Past, pt)
+		}
+	}
+	return res, nil
+}
+
+// Курсор по поездкам пассажира в порядке вылета, как TripIterator
+type PassengerTripIterator struct {
+	cursor[PassengerTrip]
+}
+
+func (it *PassengerTripIterator) PassengerTrip() PassengerTrip {
+	return it.cur
+}
+
+// Поездки пассажира так же, как GetPassengerTrips, но одним курсором
+// без деления на предстоящие и прошедшие
+func (db *AeroDB) IterPassengerTrips(passenger string, f PassengerTripFilter) (*PassengerTripIterator, error) {
+	return db.iterPassengerTrips(byName(passenger), f)
+}
+
+func (db *AeroDB) IterPassengerTripsByID(passengerID int, f PassengerTripFilter) (*PassengerTripIterator, error) {
+	return db.iterPassengerTrips(byID(passengerID), f)
+}
+
+func (db *AeroDB) iterPassengerTrips(passenger passengerRef, f PassengerTripFilter) (*PassengerTripIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	passengerID, err := passenger.resolve(db.db)
+	if err != nil {
+		return nil, err
+	}
+	cond, args := departedBetween(f.DepartAfter, f.DepartBefore)
+	if f.Company != "" {
+		companyID, err := idByName(db.db, "Company", f.Company)
+		if err != nil {
+			return nil, err
+		}
+		cond += " AND t.company_id = ?"
+		args = append(args, companyID)
+	}
+
+	// Места пассажира читаются той же строкой, чтобы курсор не занимал второе соединение
+	c, err := openCursor(db.db, scanPassengerTrip, "SELECT "+tripColumns+`,
+		(SELECT GROUP_CONCAT(seat) FROM (SELECT IFNULL(place, 0) AS seat FROM Taken
+			WHERE trip_id = t.id AND passenger_id = ? ORDER BY place))
+		FROM Trip t
+		WHERE t.id IN (SELECT trip_id FROM Taken WHERE passenger_id = ?) AND `+cond+`
+		ORDER BY julianday(t.time_out), t.id`, append([]interface{}{passengerID, passengerID}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &PassengerTripIterator{c}, nil
+}
+
+func scanPassengerTrip(rows *sql.Rows) (PassengerTrip, error) {
+	var seats string
+	t, err := scanTripWith(rows, &seats)
+	if err != nil {
+		return PassengerTrip{}, err
+	}
+	pt := PassengerTrip{Trip: t, Seats: []int{}}
+	for _, s := range strings.Split(seats, ",") {
+		seat, err := strconv.Atoi(s)
+		if err != nil {
+			return PassengerTrip{}, err
+		}
+		pt.Seats = append(pt.Seats, seat)
+	}
+	return pt, nil
+}
@@ -0,0 +1,178 @@
+package aerodb
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Бронь активна, пока не наступило время expires_at
+const activeHold = "julianday(expires_at) > julianday(?)"
+
+func newHoldToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Место свободно, если оно не заблокировано, не занято и не удерживается активной бронью
+func checkSeatFree(q querier, tripID, seat int, now time.Time) error {
+	blocked, err := exists(q, `SELECT 1 FROM Seat s JOIN Trip t ON t.plane_id = s.plane_id
+		WHERE t.id = ? AND s.place = ? AND s.attrs & ? <> 0`, tripID, seat, SeatBlocked)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%w: seat %d is blocked", ErrSeatRange, seat)
+	}
+	taken, err := exists(q, "SELECT 1 FROM Taken WHERE trip_id = ? AND place = ?", tripID, seat)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrAlreadyTaken
+	}
+	held, err := exists(q, "SELECT 1 FROM Hold WHERE trip_id = ? AND place = ? AND "+activeHold, tripID, seat, now)
+	if err != nil {
+		return err
+	}
+	if held {
+		return fmt.Errorf("%w: seat %d is held", ErrAlreadyTaken, seat)
+	}
+	return nil
+}
+
+// Удерживает место на время ttl и возвращает токен брони. Пока бронь активна,
+// место не считается свободным; истёкшая бронь освобождает место сама.
+func (db *AeroDB) HoldSeat(tripID int, seat int, ttl time.Duration) (string, error) {
+	var token string
+	err := db.inTx(func(tx *sql.Tx) (err error) {
+		token, err = holdSeat(tx, tripID, seat, ttl, db.now())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func holdSeat(tx *sql.Tx, tripID int, seat int, ttl time.Duration, now time.Time) (string, error) {
+	seats, err := tripSeats(tx, tripID)
+	if err != nil {
+		return "", err
+	}
+	if err = checkBookable(tx, tripID); err != nil {
+		return "", err
+	}
+	if seat < 1 || seat > seats {
+		return "", ErrSeatRange
+	}
+	if ttl <= 0 {
+		return "", fmt.Errorf("%w: hold ttl %v", ErrIncorectTime, ttl)
+	}
+	if err = checkSeatFree(tx, tripID, seat, now); err != nil {
+		return "", err
+	}
+
+	// Истёкшая бронь этого места больше не нужна
+	_, err = tx.Exec("DELETE FROM Hold WHERE trip_id = ? AND place = ?", tripID, seat)
+	if err != nil {
+		return "", dbError(err)
+	}
+	token, err := newHoldToken()
+	if err != nil {
+		return "", dbError(err)
+	}
+	_, err = tx.Exec("INSERT INTO Hold(trip_id, place, token, expires_at) VALUES (?, ?, ?, ?)",
+		tripID, seat, token, now.Add(ttl))
+	if err != nil {
+		return "", dbError(err)
+	}
+	return token, nil
+}
+
+// Занимает удерживаемое место за пассажиром и снимает бронь
+func (db *AeroDB) ConfirmHold(token string, passenger string) error {
+	return db.confirmHold(token, byName(passenger))
+}
+
+func (db *AeroDB) ConfirmHoldByID(token string, passengerID int) error {
+	return db.confirmHold(token, byID(passengerID))
+}
+
+func (db *AeroDB) confirmHold(token string, passenger passengerRef) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		var tripID, seat int
+		var active bool
+		err := tx.QueryRow("SELECT trip_id, place, "+activeHold+" FROM Hold WHERE token = ?",
+			db.now(), token).Scan(&tripID, &seat, &active)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+		if !active {
+			return ErrHoldExpired
+		}
+		if err = checkBookable(tx, tripID); err != nil {
+			return err
+		}
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+		if err = db.checkPassenger(tx, tripID, passengerID, false); err != nil {
+			return err
+		}
+		if err = db.checkTickets(tx, tripID, 1); err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec("DELETE FROM Hold WHERE token = ?", token); err != nil {
+			return dbError(err)
+		}
+		return insertTicket(tx, tripID, passengerID, seat, db.now())
+	})
+}
+
+// Снимает бронь, в том числе уже истёкшую. Место отдаётся первому
+// подходящему пассажиру из очереди ожидания.
+func (db *AeroDB) ReleaseHold(token string) error {
+	var promoted []Promotion
+	err := db.inTx(func(tx *sql.Tx) error {
+		var tripID, seat int
+		err := tx.QueryRow("SELECT trip_id, place FROM Hold WHERE token = ?", token).Scan(&tripID, &seat)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return dbError(err)
+		}
+		if _, err = tx.Exec("DELETE FROM Hold WHERE token = ?", token); err != nil {
+			return dbError(err)
+		}
+		// Место могли занять после истечения брони
+		err = checkSeatFree(tx, tripID, seat, db.now())
+		if errors.Is(err, ErrAlreadyTaken) || errors.Is(err, ErrSeatRange) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		p, err := db.promoteWaitlist(tx, tripID, seat, db.now())
+		if p != nil {
+			promoted = append(promoted, *p)
+		}
+		return err
+	})
+	if err == nil {
+		db.notify(promoted)
+	}
+	return err
+}
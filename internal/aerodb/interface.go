@@ -24,4 +24,6 @@ type Trip struct {
     company, plane int
     timeOut, timeIn time.Time
     townOut, townIn string
+    state TripState
+    boardingAt, departedAt, arrivedAt, cancelledAt time.Time
 }
\ No newline at end of file
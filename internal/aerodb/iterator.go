@@ -0,0 +1,168 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Курсор по строкам запроса: читает строки из базы по одной, не загружая
+// весь результат в память. Пока курсор открыт, соединение с базой занято,
+// поэтому курсор нужно закрыть вызовом Close или дочитать до конца.
+// Курсоры библиотеки встраивают его и добавляют метод текущего значения.
+type cursor[T any] struct {
+	rows *sql.Rows
+	scan func(*sql.Rows) (T, error)
+	cur  T
+	err  error
+}
+
+func openCursor[T any](q querier, scan func(*sql.Rows) (T, error), query string, args ...interface{}) (cursor[T], error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return cursor[T]{}, dbError(err)
+	}
+	return cursor[T]{rows: rows, scan: scan}, nil
+}
+
+// Переходит к следующей строке, false - строки закончились или произошла ошибка
+func (c *cursor[T]) Next() bool {
+	if c.err != nil || c.rows == nil {
+		return false
+	}
+	if !c.rows.Next() {
+		if err := c.rows.Err(); err != nil {
+			c.err = dbError(err)
+		}
+		c.Close()
+		return false
+	}
+	c.cur, c.err = c.scan(c.rows)
+	if c.err != nil {
+		c.err = dbError(c.err)
+		c.Close()
+		return false
+	}
+	return true
+}
+
+func (c *cursor[T]) Err() error {
+	return c.err
+}
+
+func (c *cursor[T]) Close() error {
+	if c.rows == nil {
+		return nil
+	}
+	err := c.rows.Close()
+	c.rows = nil
+	if err != nil {
+		return dbError(err)
+	}
+	return nil
+}
+
+// Вызывает fn для каждой строки, пока fn возвращает true, и закрывает курсор
+func (c *cursor[T]) ForEach(fn func(T) bool) error {
+	defer c.Close()
+	for c.Next() {
+		if !fn(c.cur) {
+			break
+		}
+	}
+	return c.Err()
+}
+
+// Читает все оставшиеся строки в слайс, пустой слайс - строк нет
+func (c *cursor[T]) all() ([]T, error) {
+	res := []T{}
+	err := c.ForEach(func(v T) bool {
+		res = append(res, v)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Курсор по поездкам
+//
+//	it, err := db.IterAllTrips()
+//	...
+//	defer it.Close()
+//	for it.Next() {
+//		trip := it.Trip()
+//	}
+//	err = it.Err()
+type TripIterator struct {
+	cursor[Trip]
+}
+
+func iterTrips(q querier, query string, args ...interface{}) (*TripIterator, error) {
+	c, err := openCursor(q, scanTrip, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &TripIterator{c}, nil
+}
+
+func (it *TripIterator) Trip() Trip {
+	return it.cur
+}
+
+// Читает все оставшиеся поездки в слайс, ErrEmpty - поездок нет
+func (it *TripIterator) collect() ([]Trip, error) {
+	res, err := it.all()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrEmpty
+	}
+	return res, nil
+}
+
+func queryTrips(q querier, query string, args ...interface{}) ([]Trip, error) {
+	it, err := iterTrips(q, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return it.collect()
+}
+
+func (db *AeroDB) IterTrips(from, to string) (*TripIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	return iterTrips(db.db, "SELECT "+tripColumns+" FROM Trip WHERE town_out = ? AND town_in = ? ORDER BY id", from, to)
+}
+
+func (db *AeroDB) IterAllTrips() (*TripIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	return iterTrips(db.db, "SELECT "+tripColumns+" FROM Trip ORDER BY id")
+}
+
+func (db *AeroDB) IterTripsByState(state TripState) (*TripIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	if !state.Valid() {
+		return nil, fmt.Errorf("%w: unknown state %q", ErrTripState, state)
+	}
+	return iterTrips(db.db, "SELECT "+tripColumns+" FROM Trip WHERE state = ? ORDER BY id", state)
+}
+
+// Курсор по поездкам, подходящим под условия q, с учётом порядка, Limit и Offset
+func (db *AeroDB) IterSearchTrips(q TripQuery) (*TripIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	where, args, err := q.where(db.db, db.now(), db.Overbooking)
+	if err != nil {
+		return nil, err
+	}
+	return iterTrips(db.db, "SELECT "+tripColumns+" FROM Trip"+where+q.orderBy()+" LIMIT ? OFFSET ?",
+		append(args, q.limit(), q.Offset)...)
+}
@@ -0,0 +1,178 @@
+package aerodb
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Статус билета
+type TicketStatus string
+
+const (
+	StatusBooked TicketStatus = "booked"
+)
+
+// Строка ведомости: билет пассажира. Seat = 0 - билет без места,
+// BookedAt нулевое - билет продан до того, как время продажи стало записываться.
+type ManifestRow struct {
+	Ticket      int
+	PassengerID int
+	Passenger   string
+	Seat        int
+	Label       string
+	BookedAt    time.Time
+	Status      TicketStatus
+}
+
+func (r ManifestRow) String() string {
+	return fmt.Sprintf("ticket %d: %s, seat %s, %s", r.Ticket, r.Passenger, r.Label, r.Status)
+}
+
+// Пассажирская ведомость поездки. Company и Plane пустые, если
+// компания или самолёт поездки удалены.
+type Manifest struct {
+	Trip            int
+	Company         string
+	Plane           string
+	TownOut, TownIn string
+	TimeOut, TimeIn time.Time
+	State           TripState
+	Rows            []ManifestRow
+}
+
+// Ведомость поездки: билеты по местам, билеты без мест - в конце в порядке продажи.
+// Для поездки без билетов Rows пустой.
+func (db *AeroDB) GetManifest(tripID int) (Manifest, error) {
+	if db.db == nil {
+		return Manifest{}, ErrNotOpened
+	}
+	m := Manifest{Rows: []ManifestRow{}}
+	err := db.db.QueryRow(`SELECT t.id, IFNULL(c.name, ''), IFNULL(p.name, ''),
+		t.town_out, t.town_in, t.time_out, t.time_in, t.state
+		FROM Trip t
+		LEFT JOIN Company c ON c.id = t.company_id
+		LEFT JOIN Plane p ON p.id = t.plane_id
+		WHERE t.id = ?`, tripID).Scan(&m.Trip, &m.Company, &m.Plane,
+		&m.TownOut, &m.TownIn, &m.TimeOut, &m.TimeIn, &m.State)
+	if err == sql.ErrNoRows {
+		return Manifest{}, ErrNotFound
+	}
+	if err != nil {
+		return Manifest{}, dbError(err)
+	}
+
+	seatMap, err := tripSeatMap(db.db, tripID)
+	if err != nil {
+		return Manifest{}, err
+	}
+	rows, err := db.db.Query(`SELECT k.id, k.passenger_id, IFNULL(p.name, ''), IFNULL(k.place, 0), k.booked_at, k.status
+		FROM Taken k JOIN Passenger p ON p.id = k.passenger_id
+		WHERE k.trip_id = ?
+		ORDER BY k.place IS NULL, k.place, k.id`, tripID)
+	if err != nil {
+		return Manifest{}, dbError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r ManifestRow
+		var booked sql.NullTime
+		if err = rows.Scan(&r.Ticket, &r.PassengerID, &r.Passenger, &r.Seat, &booked, &r.Status); err != nil {
+			return Manifest{}, dbError(err)
+		}
+		r.BookedAt = booked.Time
+		if r.Seat > 0 && r.Seat <= len(seatMap) {
+			r.Label = seatMap[r.Seat-1].Label()
+		}
+		m.Rows = append(m.Rows, r)
+	}
+	if err = rows.Err(); err != nil {
+		return Manifest{}, dbError(err)
+	}
+	return m, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Записывает ведомость в CSV: строка заголовка поездки и её значения,
+// затем строка заголовка билетов и по строке на каждый билет
+func (m Manifest) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{
+		{"trip", "company", "plane", "town_out", "town_in", "time_out", "time_in", "state"},
+		{strconv.Itoa(m.Trip), m.Company, m.Plane, m.TownOut, m.TownIn,
+			formatTime(m.TimeOut), formatTime(m.TimeIn), string(m.State)},
+		{"ticket", "passenger_id", "passenger", "seat", "label", "booked_at", "status"},
+	}
+	for _, r := range m.Rows {
+		seat := ""
+		if r.Seat > 0 {
+			seat = strconv.Itoa(r.Seat)
+		}
+		records = append(records, []string{strconv.Itoa(r.Ticket), strconv.Itoa(r.PassengerID), r.Passenger,
+			seat, r.Label, formatTime(r.BookedAt), string(r.Status)})
+	}
+	return cw.WriteAll(records)
+}
+
+type manifestRowJSON struct {
+	Ticket      int          `json:"ticket"`
+	PassengerID int          `json:"passenger_id"`
+	Passenger   string       `json:"passenger"`
+	Seat        int          `json:"seat,omitempty"`
+	Label       string       `json:"label,omitempty"`
+	BookedAt    *time.Time   `json:"booked_at,omitempty"`
+	Status      TicketStatus `json:"status"`
+}
+
+type manifestJSON struct {
+	Trip    int               `json:"trip"`
+	Company string            `json:"company"`
+	Plane   string            `json:"plane"`
+	TownOut string            `json:"town_out"`
+	TownIn  string            `json:"town_in"`
+	TimeOut time.Time         `json:"time_out"`
+	TimeIn  time.Time         `json:"time_in"`
+	State   TripState         `json:"state"`
+	Rows    []manifestRowJSON `json:"passengers"`
+}
+
+// Записывает ведомость в JSON одним объектом
+func (m Manifest) WriteJSON(w io.Writer) error {
+	j := manifestJSON{
+		Trip:    m.Trip,
+		Company: m.Company,
+		Plane:   m.Plane,
+		TownOut: m.TownOut,
+		TownIn:  m.TownIn,
+		TimeOut: m.TimeOut,
+		TimeIn:  m.TimeIn,
+		State:   m.State,
+		Rows:    make([]manifestRowJSON, len(m.Rows)),
+	}
+	for i, r := range m.Rows {
+		j.Rows[i] = manifestRowJSON{
+			Ticket:      r.Ticket,
+			PassengerID: r.PassengerID,
+			Passenger:   r.Passenger,
+			Seat:        r.Seat,
+			Label:       r.Label,
+			Status:      r.Status,
+		}
+		if !r.BookedAt.IsZero() {
+			booked := r.BookedAt
+			j.Rows[i].BookedAt = &booked
+		}
+	}
+	return json.NewEncoder(w).Encode(j)
+}
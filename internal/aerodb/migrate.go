@@ -0,0 +1,187 @@
+package aerodb
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// Миграция схемы: файл migrations/NNNN_name.sql, где NNNN - номер версии
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+func loadMigrations() ([]migration, error) {
+	entries, err := migrationFS.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var res []migration
+	for _, e := range entries {
+		fname := e.Name()
+		num, name, ok := strings.Cut(strings.TrimSuffix(fname, ".sql"), "_")
+		if !ok {
+			return nil, fmt.Errorf("bad migration name %q", fname)
+		}
+		version, err := strconv.Atoi(num)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("bad migration version %q", fname)
+		}
+		b, err := migrationFS.ReadFile(path.Join("migrations", fname))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, migration{version: version, name: name, sql: string(b)})
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i].version < res[j].version })
+	for i := range res {
+		if res[i].version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+	}
+	return res, nil
+}
+
+// Версия схемы, которую поддерживает библиотека
+func LatestSchemaVersion() int {
+	ms, err := loadMigrations()
+	if err != nil {
+		return 0
+	}
+	return len(ms)
+}
+
+// Текущая версия схемы открытой базы данных (0 - миграции не применялись)
+func (db *AeroDB) SchemaVersion() (int, error) {
+	if db.db == nil {
+		return 0, ErrNotOpened
+	}
+	return schemaVersion(db.db)
+}
+
+func schemaVersion(q querier) (int, error) {
+	ok, err := exists(q, "SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_version'")
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	var version int
+	err = q.QueryRow("SELECT IFNULL(MAX(version), 0) FROM schema_version").Scan(&version)
+	if err != nil {
+		return 0, dbError(err)
+	}
+	return version, nil
+}
+
+// Применяет к открытой базе все ещё не применённые миграции.
+// Каждая миграция выполняется в отдельной транзакции.
+func (db *AeroDB) Migrate() error {
+	if db.db == nil {
+		return ErrNotOpened
+	}
+	if err := db.migrate(); err != nil {
+		return err
+	}
+	return db.validate()
+}
+
+func (db *AeroDB) migrate() error {
+	return migrateTo(db.db, -1)
+}
+
+// Применяет миграции до версии target включительно (-1 - до последней)
+func migrateTo(conn *sql.DB, target int) error {
+	ms, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDB, err)
+	}
+	if target < 0 || target > len(ms) {
+		target = len(ms)
+	}
+
+	current, err := schemaVersion(conn)
+	if err != nil {
+		return err
+	}
+	if current > len(ms) {
+		return fmt.Errorf("%w: database version %d, supported %d", ErrSchemaVersion, current, len(ms))
+	}
+
+	for _, m := range ms[current:target] {
+		if err = applyMigration(conn, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Миграции, пересоздающие таблицы, на которые ссылаются другие таблицы,
+// выполняются с отключёнными внешними ключами (отключить их можно только вне
+// транзакции), а перед фиксацией ссылки проверяются foreign_key_check.
+const noForeignKeys = "-- foreign_keys: off"
+
+func applyMigration(conn *sql.DB, m migration) error {
+	ctx := context.Background()
+	// Прагмы действуют на соединение, поэтому миграция выполняется в одном соединении
+	c, err := conn.Conn(ctx)
+	if err != nil {
+		return dbError(err)
+	}
+	defer c.Close()
+
+	noFK := strings.Contains(m.sql, noForeignKeys)
+	if noFK {
+		if _, err = c.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+			return dbError(err)
+		}
+		defer c.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+	}
+
+	tx, err := c.BeginTx(ctx, nil)
+	if err != nil {
+		return dbError(err)
+	}
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER primary key,
+		name VARCHAR
+	)`)
+	if err != nil {
+		tx.Rollback()
+		return dbError(err)
+	}
+	if _, err = tx.Exec(m.sql); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%w: migration %d (%s): %w", ErrDB, m.version, m.name, err)
+	}
+	if noFK {
+		broken, err := exists(tx, "SELECT 1 FROM pragma_foreign_key_check")
+		if err != nil || broken {
+			tx.Rollback()
+			if err == nil {
+				err = fmt.Errorf("%w: migration %d (%s) breaks foreign keys", ErrDB, m.version, m.name)
+			}
+			return err
+		}
+	}
+	_, err = tx.Exec("INSERT INTO schema_version(version, name) VALUES (?, ?)", m.version, m.name)
+	if err != nil {
+		tx.Rollback()
+		return dbError(err)
+	}
+	if err = tx.Commit(); err != nil {
+		return dbError(err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+-- Исходная схема базы перелётов. IF NOT EXISTS позволяет принять под управление
+-- базы, созданные до появления миграций.
+CREATE TABLE IF NOT EXISTS Trip (
+    id INTEGER primary key,
+    company_id INTEGER,
+    plane_id INTEGER,
+    time_out TIMESTAMP,
+    time_in TIMESTAMP,
+    town_out VARCHAR,
+    town_in VARCHAR
+);
+
+CREATE TABLE IF NOT EXISTS Plane (
+    id INTEGER primary key,
+    name VARCHAR UNIQUE,
+    company_id INTEGER,
+    seats INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS Company (
+    id INTEGER primary key,
+    name VARCHAR UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS Passenger (
+    id INTEGER primary key,
+    name VARCHAR UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS Taken (
+    id INTEGER primary key,
+    trip_id INTEGER,
+    passenger_id INTEGER,
+    place INTEGER
+);
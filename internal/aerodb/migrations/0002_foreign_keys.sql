@@ -0,0 +1,62 @@
+-- Пересоздание таблиц с внешними ключами и индексами.
+-- Старые таблицы сначала переименовываются: на них никто не ссылается,
+-- поэтому новые таблицы сразу ссылаются на окончательные имена.
+ALTER TABLE Trip RENAME TO Trip_old;
+ALTER TABLE Plane RENAME TO Plane_old;
+ALTER TABLE Company RENAME TO Company_old;
+ALTER TABLE Passenger RENAME TO Passenger_old;
+ALTER TABLE Taken RENAME TO Taken_old;
+
+CREATE TABLE Company (
+    id INTEGER primary key,
+    name VARCHAR UNIQUE
+);
+
+CREATE TABLE Passenger (
+    id INTEGER primary key,
+    name VARCHAR UNIQUE
+);
+
+CREATE TABLE Plane (
+    id INTEGER primary key,
+    name VARCHAR UNIQUE,
+    company_id INTEGER REFERENCES Company(id),
+    seats INTEGER
+);
+
+CREATE TABLE Trip (
+    id INTEGER primary key,
+    company_id INTEGER REFERENCES Company(id),
+    plane_id INTEGER REFERENCES Plane(id),
+    time_out TIMESTAMP,
+    time_in TIMESTAMP,
+    town_out VARCHAR,
+    town_in VARCHAR
+);
+
+CREATE TABLE Taken (
+    id INTEGER primary key,
+    trip_id INTEGER REFERENCES Trip(id),
+    passenger_id INTEGER REFERENCES Passenger(id),
+    place INTEGER
+);
+
+INSERT INTO Company(id, name) SELECT id, name FROM Company_old;
+INSERT INTO Passenger(id, name) SELECT id, name FROM Passenger_old;
+INSERT INTO Plane(id, name, company_id, seats) SELECT id, name, company_id, seats FROM Plane_old;
+INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+    SELECT id, company_id, plane_id, time_out, time_in, town_out, town_in FROM Trip_old;
+INSERT INTO Taken(id, trip_id, passenger_id, place) SELECT id, trip_id, passenger_id, place FROM Taken_old;
+
+DROP TABLE Taken_old;
+DROP TABLE Trip_old;
+DROP TABLE Plane_old;
+DROP TABLE Passenger_old;
+DROP TABLE Company_old;
+
+CREATE INDEX Plane_company ON Plane(company_id);
+CREATE INDEX Trip_company ON Trip(company_id);
+CREATE INDEX Trip_plane ON Trip(plane_id);
+CREATE INDEX Trip_route ON Trip(town_out, town_in);
+CREATE UNIQUE INDEX Taken_place ON Taken(trip_id, place);
+CREATE INDEX Taken_passenger ON Taken(passenger_id);
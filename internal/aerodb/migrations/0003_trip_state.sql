@@ -0,0 +1,8 @@
+-- Состояния поездки и время перехода в каждое из них
+ALTER TABLE Trip ADD COLUMN state VARCHAR NOT NULL DEFAULT 'scheduled';
+ALTER TABLE Trip ADD COLUMN boarding_at TIMESTAMP;
+ALTER TABLE Trip ADD COLUMN departed_at TIMESTAMP;
+ALTER TABLE Trip ADD COLUMN arrived_at TIMESTAMP;
+ALTER TABLE Trip ADD COLUMN cancelled_at TIMESTAMP;
+
+CREATE INDEX Trip_state ON Trip(state);
@@ -0,0 +1,11 @@
+-- Временные брони мест, истёкшие брони считаются свободными местами
+CREATE TABLE Hold (
+    id INTEGER primary key,
+    trip_id INTEGER NOT NULL REFERENCES Trip(id) ON DELETE CASCADE,
+    place INTEGER NOT NULL,
+    token VARCHAR NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX Hold_token ON Hold(token);
+CREATE UNIQUE INDEX Hold_place ON Hold(trip_id, place);
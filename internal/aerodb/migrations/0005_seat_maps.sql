@@ -0,0 +1,14 @@
+-- Схемы салонов самолётов. Самолёты без строк в Seat используют схему
+-- по умолчанию, которая строится по количеству мест.
+CREATE TABLE Seat (
+    id INTEGER primary key,
+    plane_id INTEGER NOT NULL REFERENCES Plane(id) ON DELETE CASCADE,
+    place INTEGER NOT NULL,
+    row_num INTEGER NOT NULL,
+    letter VARCHAR NOT NULL,
+    cabin VARCHAR NOT NULL,
+    attrs INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX Seat_place ON Seat(plane_id, place);
+CREATE UNIQUE INDEX Seat_label ON Seat(plane_id, row_num, letter);
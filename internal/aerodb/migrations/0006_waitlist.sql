@@ -0,0 +1,11 @@
+-- Очередь ожидания мест на поездку, cabin = '' - подходит место любого класса
+CREATE TABLE Waitlist (
+    id INTEGER primary key,
+    trip_id INTEGER NOT NULL REFERENCES Trip(id) ON DELETE CASCADE,
+    passenger_id INTEGER NOT NULL REFERENCES Passenger(id),
+    cabin VARCHAR NOT NULL DEFAULT '',
+    position INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX Waitlist_passenger ON Waitlist(trip_id, passenger_id);
+CREATE INDEX Waitlist_position ON Waitlist(trip_id, position);
@@ -0,0 +1,21 @@
+-- Тарифы поездок по классам и цены билетов в минимальных единицах валюты (копейках)
+CREATE TABLE Fare (
+    id INTEGER primary key,
+    trip_id INTEGER NOT NULL REFERENCES Trip(id) ON DELETE CASCADE,
+    cabin VARCHAR NOT NULL,
+    base INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX Fare_cabin ON Fare(trip_id, cabin);
+
+-- Цена при загрузке поездки от load_factor процентов
+CREATE TABLE FareTier (
+    id INTEGER primary key,
+    fare_id INTEGER NOT NULL REFERENCES Fare(id) ON DELETE CASCADE,
+    load_factor INTEGER NOT NULL,
+    price INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX FareTier_load ON FareTier(fare_id, load_factor);
+
+ALTER TABLE Taken ADD COLUMN price INTEGER;
@@ -0,0 +1,20 @@
+-- foreign_keys: off
+-- Пассажиры различаются по id, имя больше не уникально. Таблица пересоздаётся,
+-- потому что ограничение UNIQUE нельзя снять через ALTER TABLE.
+CREATE TABLE Passenger_new (
+    id INTEGER primary key,
+    name VARCHAR,
+    given_name VARCHAR NOT NULL DEFAULT '',
+    family_name VARCHAR NOT NULL DEFAULT '',
+    birth_date DATE,
+    doc_type VARCHAR,
+    doc_number VARCHAR,
+    contact VARCHAR NOT NULL DEFAULT ''
+);
+
+INSERT INTO Passenger_new(id, name) SELECT id, name FROM Passenger;
+DROP TABLE Passenger;
+ALTER TABLE Passenger_new RENAME TO Passenger;
+
+CREATE INDEX Passenger_name ON Passenger(name);
+CREATE UNIQUE INDEX Passenger_document ON Passenger(doc_type, doc_number);
@@ -0,0 +1,3 @@
+-- Время продажи билета (у билетов, проданных до этой миграции, неизвестно) и его статус
+ALTER TABLE Taken ADD COLUMN booked_at DATETIME;
+ALTER TABLE Taken ADD COLUMN status VARCHAR NOT NULL DEFAULT 'booked';
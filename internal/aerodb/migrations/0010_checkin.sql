@@ -0,0 +1,6 @@
+-- Выход на посадку поездки и регистрация билетов: порядковый номер регистрации в поездке и её время
+ALTER TABLE Trip ADD COLUMN gate VARCHAR NOT NULL DEFAULT '';
+ALTER TABLE Taken ADD COLUMN checkin_seq INTEGER;
+ALTER TABLE Taken ADD COLUMN checked_in_at DATETIME;
+
+CREATE UNIQUE INDEX Taken_checkin ON Taken(trip_id, checkin_seq);
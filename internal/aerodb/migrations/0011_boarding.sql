@@ -0,0 +1,2 @@
+-- Время посадки пассажира по билету
+ALTER TABLE Taken ADD COLUMN boarded_at DATETIME;
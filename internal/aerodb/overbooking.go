@@ -0,0 +1,218 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Билет без места, которому при посадке не хватит места
+type Denial struct {
+	Ticket    int
+	Passenger string
+}
+
+func (d Denial) String() string {
+	return fmt.Sprintf("ticket %d (%s)", d.Ticket, d.Passenger)
+}
+
+// Сколько билетов можно продать на поездку: число мест плюс процент
+// перебронирования компании
+func (db *AeroDB) ticketLimit(q querier, tripID int) (limit, sold int, err error) {
+	return db.planeTicketLimit(q, tripID, 0)
+}
+
+// То же, если поездку выполняет самолёт planeID (0 - самолёт поездки)
+func (db *AeroDB) planeTicketLimit(q querier, tripID, planeID int) (limit, sold int, err error) {
+	var seats int
+	var company sql.NullString
+	err = q.QueryRow(`SELECT p.seats, c.name, (SELECT COUNT(*) FROM Taken WHERE trip_id = t.id AND status <> ?)
+		FROM Trip t
+		JOIN Plane p ON p.id = CASE ? WHEN 0 THEN t.plane_id ELSE ? END
+		LEFT JOIN Company c ON c.id = t.company_id
+		WHERE t.id = ?`, StatusNoShow, planeID, planeID, tripID).Scan(&seats, &company, &sold)
+	if err == sql.ErrNoRows {
+		return 0, 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, 0, dbError(err)
+	}
+	return seats + seats*db.Overbooking[company.String]/100, sold, nil
+}
+
+// Проверяет, что на поездку можно продать ещё n билетов
+func (db *AeroDB) checkTickets(q querier, tripID, n int) error {
+	limit, sold, err := db.ticketLimit(q, tripID)
+	if err != nil {
+		return err
+	}
+	if sold+n > limit {
+		return fmt.Errorf("%w: %d of %d tickets sold", ErrOverbooked, sold, limit)
+	}
+	return nil
+}
+
+// Свободные места поездки, которые можно купить сейчас, и сколько ещё билетов
+// с местом можно продать: не больше свободных мест и не больше остатка лимита
+// билетов. Когда лимит исчерпан билетами без мест, свободных мест нет.
+func (db *AeroDB) openSeats(q querier, tripID int, now time.Time) ([]int, int, error) {
+	free, err := freeSeats(q, tripID, now)
+	if err != nil {
+		return nil, 0, err
+	}
+	limit, sold, err := db.ticketLimit(q, tripID)
+	if err != nil {
+		return nil, 0, err
+	}
+	left := limit - sold
+	if left <= 0 {
+		return []int{}, 0, nil
+	}
+	if left > len(free) {
+		left = len(free)
+	}
+	return free, left, nil
+}
+
+// Сколько ещё билетов с местом можно продать на поездку Trip в SQL-запросах
+// к таблице Trip, считается так же, как в openSeats. Возвращает выражение
+// и его параметры: текущее время для проверки броней и проценты перебронирования.
+func ticketsLeftSQL(now time.Time, overbooking map[string]int) (string, []interface{}) {
+	seats := "(SELECT seats FROM Plane WHERE Plane.id = Trip.plane_id)"
+	percent := "0"
+	var args []interface{}
+	if len(overbooking) > 0 {
+		companies := make([]string, 0, len(overbooking))
+		for name := range overbooking {
+			companies = append(companies, name)
+		}
+		sort.Strings(companies)
+		percent = "CASE (SELECT name FROM Company WHERE Company.id = Trip.company_id)"
+		for _, name := range companies {
+			percent += " WHEN ? THEN ?"
+			args = append(args, name, overbooking[name])
+		}
+		percent += " ELSE 0 END"
+	}
+	limit := "(" + seats + " + " + seats + " * " + percent + " / 100 - " +
+		"(SELECT COUNT(*) FROM Taken WHERE Taken.trip_id = Trip.id AND status <> ?))"
+	return "MIN(" + freeSeatsCount + ", " + limit + ")", append(append([]interface{}{now}, args...), StatusNoShow)
+}
+
+// Продаёт пассажиру билет без места, место назначается позже (AssignSeat).
+// Всего можно продать не больше билетов, чем мест в самолёте с учётом
+// процента перебронирования компании из поля Overbooking.
+func (db *AeroDB) SellTicket(tripID int, passenger string) error {
+	return db.sellTicket(tripID, byName(passenger))
+}
+
+func (db *AeroDB) SellTicketByID(tripID int, passengerID int) error {
+	return db.sellTicket(tripID, byID(passengerID))
+}
+
+func (db *AeroDB) sellTicket(tripID int, passenger passengerRef) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		if _, err := tripSeats(tx, tripID); err != nil {
+			return err
+		}
+		if err := checkBookable(tx, tripID); err != nil {
+			return err
+		}
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+		if err = db.checkPassenger(tx, tripID, passengerID, false); err != nil {
+			return err
+		}
+		if err = db.checkTickets(tx, tripID, 1); err != nil {
+			return err
+		}
+		return insertTicket(tx, tripID, passengerID, 0, db.now())
+	})
+}
+
+// Назначает место по первому билету без места пассажира
+func (db *AeroDB) AssignSeat(tripID int, passenger string, seat int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		return db.assignSeat(tx, tripID, byName(passenger), seat)
+	})
+}
+
+func (db *AeroDB) AssignSeatByID(tripID int, passengerID int, seat int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		return db.assignSeat(tx, tripID, byID(passengerID), seat)
+	})
+}
+
+func (db *AeroDB) assignSeat(tx *sql.Tx, tripID int, passenger passengerRef, seat int) error {
+	seats, err := tripSeats(tx, tripID)
+	if err != nil {
+		return err
+	}
+	if err = checkBookable(tx, tripID); err != nil {
+		return err
+	}
+	passengerID, err := passenger.resolve(tx)
+	if err != nil {
+		return err
+	}
+	var ticket int
+	err = tx.QueryRow(`SELECT id FROM Taken WHERE trip_id = ? AND passenger_id = ? AND place IS NULL AND status <> ?
+		ORDER BY id LIMIT 1`, tripID, passengerID, StatusNoShow).Scan(&ticket)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s has no ticket without seat", ErrNotFound, passenger)
+	}
+	if err != nil {
+		return dbError(err)
+	}
+	if seat < 1 || seat > seats {
+		return ErrSeatRange
+	}
+	if err = checkSeatFree(tx, tripID, seat, db.now()); err != nil {
+		return err
+	}
+	if _, err = tx.Exec("UPDATE Taken SET place = ? WHERE id = ?", seat, ticket); err != nil {
+		return dbError(err)
+	}
+	return nil
+}
+
+// Билеты без мест, которым не хватит свободных мест поездки. Места получают
+// билеты в порядке продажи, отказ получают проданные последними.
+// Пустой слайс - мест хватает всем.
+func (db *AeroDB) DeniedBoarding(tripID int) ([]Denial, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	return deniedBoarding(db.db, tripID, db.now())
+}
+
+func deniedBoarding(q querier, tripID int, now time.Time) ([]Denial, error) {
+	free, err := freeSeats(q, tripID, now)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.Query(`SELECT t.id, p.name FROM Taken t
+		JOIN Passenger p ON p.id = t.passenger_id
+		WHERE t.trip_id = ? AND t.place IS NULL AND t.status <> ?
+		ORDER BY t.id LIMIT -1 OFFSET ?`, tripID, StatusNoShow, len(free))
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer rows.Close()
+
+	res := []Denial{}
+	for rows.Next() {
+		var d Denial
+		if err = rows.Scan(&d.Ticket, &d.Passenger); err != nil {
+			return nil, dbError(err)
+		}
+		res = append(res, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dbError(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,187 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Данные пассажира. Пассажиры, добавленные через AddPassenger, имеют только Name.
+type PassengerInfo struct {
+	ID         int
+	Name       string
+	GivenName  string
+	FamilyName string
+	BirthDate  time.Time
+	DocType    string
+	DocNumber  string
+	Contact    string
+}
+
+func (p PassengerInfo) String() string {
+	return fmt.Sprintf("Passenger %d: %s", p.ID, p.Name)
+}
+
+// Ссылка на пассажира: по id или, для совместимости, по имени
+type passengerRef struct {
+	id   int
+	name string
+}
+
+func byName(name string) passengerRef {
+	return passengerRef{name: name}
+}
+
+func byID(id int) passengerRef {
+	return passengerRef{id: id}
+}
+
+func (r passengerRef) String() string {
+	if r.id != 0 {
+		return "passenger " + strconv.Itoa(r.id)
+	}
+	return r.name
+}
+
+// Возвращает id пассажира. Имя должно быть у единственного пассажира,
+// иначе ErrAmbiguous: таких пассажиров нужно указывать по id.
+func (r passengerRef) resolve(q querier) (int, error) {
+	if r.id != 0 {
+		ok, err := exists(q, "SELECT 1 FROM Passenger WHERE id = ?", r.id)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, ErrNotFound
+		}
+		return r.id, nil
+	}
+
+	ids, err := queryInts(q, "SELECT id FROM Passenger WHERE name = ? LIMIT 2", r.name)
+	if err != nil {
+		return 0, dbError(err)
+	}
+	switch len(ids) {
+	case 0:
+		return 0, ErrNotFound
+	case 1:
+		return ids[0], nil
+	}
+	return 0, fmt.Errorf("%w: several passengers named %q", ErrAmbiguous, r.name)
+}
+
+// Добавляет пассажира только с именем. Имя должно быть свободно.
+func (db *AeroDB) AddPassenger(name string) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		in, err := exists(tx, "SELECT 1 FROM Passenger WHERE name = ?", name)
+		if err != nil {
+			return err
+		}
+		if in {
+			return ErrAlreadyIn
+		}
+		if _, err = tx.Exec("INSERT INTO Passenger(name) VALUES (?)", name); err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+// Добавляет пассажира с личными данными и возвращает его id. Имена могут
+// повторяться, документ (тип и номер) - нет. Name по умолчанию - имя и фамилия.
+func (db *AeroDB) AddPassengerInfo(p PassengerInfo) (int, error) {
+	p.GivenName = strings.TrimSpace(p.GivenName)
+	p.FamilyName = strings.TrimSpace(p.FamilyName)
+	if p.Name == "" {
+		p.Name = strings.TrimSpace(p.GivenName + " " + p.FamilyName)
+	}
+	if p.Name == "" {
+		return 0, fmt.Errorf("%w: passenger has no name", ErrPassenger)
+	}
+	if (p.DocType == "") != (p.DocNumber == "") {
+		return 0, fmt.Errorf("%w: document needs both type and number", ErrPassenger)
+	}
+	if !p.BirthDate.IsZero() && p.BirthDate.After(db.now()) {
+		return 0, fmt.Errorf("%w: birth date %s is in the future", ErrPassenger, p.BirthDate.Format(time.DateOnly))
+	}
+
+	var id int
+	err := db.inTx(func(tx *sql.Tx) error {
+		if p.DocType != "" {
+			in, err := exists(tx, "SELECT 1 FROM Passenger WHERE doc_type = ? AND doc_number = ?", p.DocType, p.DocNumber)
+			if err != nil {
+				return err
+			}
+			if in {
+				return ErrAlreadyIn
+			}
+		}
+		var birth, docType, docNumber interface{}
+		if !p.BirthDate.IsZero() {
+			birth = p.BirthDate.Format(time.DateOnly)
+		}
+		if p.DocType != "" {
+			docType, docNumber = p.DocType, p.DocNumber
+		}
+		res, err := tx.Exec(`INSERT INTO Passenger(name, given_name, family_name, birth_date, doc_type, doc_number, contact)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`, p.Name, p.GivenName, p.FamilyName, birth, docType, docNumber, p.Contact)
+		if err != nil {
+			return dbError(err)
+		}
+		n, err := res.LastInsertId()
+		if err != nil {
+			return dbError(err)
+		}
+		id = int(n)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+const passengerColumns = "id, IFNULL(name, ''), given_name, family_name, IFNULL(birth_date, ''), IFNULL(doc_type, ''), IFNULL(doc_number, ''), contact"
+
+func scanPassenger(row *sql.Row) (PassengerInfo, error) {
+	var p PassengerInfo
+	var birth string
+	err := row.Scan(&p.ID, &p.Name, &p.GivenName, &p.FamilyName, &birth, &p.DocType, &p.DocNumber, &p.Contact)
+	if err == sql.ErrNoRows {
+		return PassengerInfo{}, ErrNotFound
+	}
+	if err != nil {
+		return PassengerInfo{}, dbError(err)
+	}
+	if birth != "" {
+		if p.BirthDate, err = time.Parse(time.DateOnly, birth); err != nil {
+			return PassengerInfo{}, dbError(err)
+		}
+	}
+	return p, nil
+}
+
+func (db *AeroDB) GetPassenger(id int) (PassengerInfo, error) {
+	if db.db == nil {
+		return PassengerInfo{}, ErrNotOpened
+	}
+	return scanPassenger(db.db.QueryRow("SELECT "+passengerColumns+" FROM Passenger WHERE id = ?", id))
+}
+
+func (db *AeroDB) FindPassengerByDocument(docType, docNumber string) (PassengerInfo, error) {
+	if db.db == nil {
+		return PassengerInfo{}, ErrNotOpened
+	}
+	return scanPassenger(db.db.QueryRow("SELECT "+passengerColumns+" FROM Passenger WHERE doc_type = ? AND doc_number = ?",
+		docType, docNumber))
+}
+
+// id пассажира по имени, для перехода со старых вызовов по имени
+func (db *AeroDB) PassengerID(name string) (int, error) {
+	if db.db == nil {
+		return 0, ErrNotOpened
+	}
+	return byName(name).resolve(db.db)
+}
@@ -0,0 +1,155 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func (db *AeroDB) AddPlane(name, companyName string, seats int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		in, err := exists(tx, "SELECT 1 FROM Plane WHERE name = ?", name)
+		if err != nil {
+			return err
+		}
+		if in {
+			return ErrAlreadyIn
+		}
+		companyID, err := idByName(tx, "Company", companyName)
+		if err != nil {
+			return err
+		}
+		if seats <= 0 {
+			return ErrSeatRange
+		}
+		_, err = tx.Exec("INSERT INTO Plane(name, company_id, seats) VALUES (?, ?, ?)", name, companyID, seats)
+		if err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+// Удаляет самолёт. Поездки самолёта передаются наследнику heritant,
+// если наследник не указан, то удаляются вместе с занятыми местами.
+func (db *AeroDB) DelPlane(name, heritant string) error {
+	_, err := db.DelPlaneReport(name, heritant, DelPlaneOptions{})
+	return err
+}
+
+type DelPlaneOptions struct {
+	// Пересаживать пассажиров, чьих мест нет в самолёте-наследнике,
+	// иначе такие места - конфликт
+	Remap SeatRemap
+	// Разрешить наследника из другой компании
+	AllowOtherCompany bool
+	// Только подготовить отчёт, не изменяя базу
+	DryRun bool
+}
+
+// Что при удалении самолёта передано наследнику Heir (или удалено, если Heir пустой):
+// поездки самолёта и пересаженные пассажиры
+type PlaneTransfer struct {
+	Plane   string
+	Heir    string
+	Trips   []int
+	Changes []SeatChange
+	DryRun  bool
+}
+
+func (r PlaneTransfer) String() string {
+	action := "deleted"
+	if r.Heir != "" {
+		action = "-> " + r.Heir
+	}
+	if r.DryRun {
+		action += " (dry run)"
+	}
+	return fmt.Sprintf("%s %s: trips %v, seat changes %v", r.Plane, action, r.Trips, r.Changes)
+}
+
+// Удаляет самолёт так же, как DelPlane, и возвращает отчёт. Перед передачей
+// поездок проверяется, что наследник свободен во время незавершённых поездок
+// и что места их билетов есть в его схеме салона. Если места не подходят,
+// возвращается *SeatConflictError со списком всех конфликтов по всем поездкам,
+// либо пассажиры пересаживаются согласно opts.Remap.
+func (db *AeroDB) DelPlaneReport(name, heritant string, opts DelPlaneOptions) (PlaneTransfer, error) {
+	report := PlaneTransfer{Plane: name, Heir: heritant, DryRun: opts.DryRun}
+	err := db.inTx(func(tx *sql.Tx) error {
+		planeID, err := idByName(tx, "Plane", name)
+		if err != nil {
+			return err
+		}
+		if report.Trips, err = queryInts(tx, "SELECT id FROM Trip WHERE plane_id = ? ORDER BY id", planeID); err != nil {
+			return dbError(err)
+		}
+
+		if heritant == "" {
+			_, err = tx.Exec("DELETE FROM Taken WHERE trip_id IN (SELECT id FROM Trip WHERE plane_id = ?)", planeID)
+			if err != nil {
+				return dbError(err)
+			}
+			if _, err = tx.Exec("DELETE FROM Trip WHERE plane_id = ?", planeID); err != nil {
+				return dbError(err)
+			}
+		} else {
+			if heritant == name {
+				return ErrNotFound
+			}
+			heirID, err := idByName(tx, "Plane", heritant)
+			if err != nil {
+				return err
+			}
+			if !opts.AllowOtherCompany {
+				same, err := exists(tx, `SELECT 1 FROM Plane p, Plane h
+					WHERE p.id = ? AND h.id = ? AND p.company_id IS h.company_id`, planeID, heirID)
+				if err != nil {
+					return err
+				}
+				if !same {
+					return fmt.Errorf("%w: %s", ErrHeirCompany, heritant)
+				}
+			}
+
+			trips, err := planeTrips(tx, planeID)
+			if err != nil {
+				return err
+			}
+			var conflicts []SeatConflict
+			now := db.now()
+			// Завершённые поездки переходят к наследнику как есть:
+			// их билеты - история, места не проверяются и не меняются
+			for _, t := range trips {
+				if t.state.Final() {
+					continue
+				}
+				if err = checkPlaneFree(tx, heirID, t.timeOut, t.timeIn, 0); err != nil {
+					return err
+				}
+				changes, c, err := db.refitTrip(tx, t.id, heirID, opts.Remap, now)
+				if err != nil {
+					return err
+				}
+				report.Changes = append(report.Changes, changes...)
+				conflicts = append(conflicts, c...)
+			}
+			if len(conflicts) > 0 {
+				return &SeatConflictError{Conflicts: conflicts}
+			}
+			if _, err = tx.Exec("UPDATE Trip SET plane_id = ? WHERE plane_id = ?", heirID, planeID); err != nil {
+				return dbError(err)
+			}
+		}
+
+		if _, err = tx.Exec("DELETE FROM Plane WHERE id = ?", planeID); err != nil {
+			return dbError(err)
+		}
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		return PlaneTransfer{}, err
+	}
+	return report, nil
+}
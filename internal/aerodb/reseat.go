@@ -0,0 +1,287 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Как пересаживать пассажиров, чьих мест нет в другом самолёте
+type SeatRemap int
+
+const (
+	// Не пересаживать: такие места - конфликт
+	RemapNone SeatRemap = iota
+	// Место с тем же обозначением, иначе первое свободное место того же класса,
+	// иначе первое свободное место
+	RemapByLabel
+	// Первые свободные места по порядку номеров
+	RemapByOrder
+)
+
+// Пассажир, место которого изменилось при смене самолёта поездки
+type SeatChange struct {
+	Trip      int
+	Ticket    int
+	Passenger string
+	OldSeat   int
+	OldLabel  string
+	NewSeat   int
+	NewLabel  string
+}
+
+func (c SeatChange) String() string {
+	return fmt.Sprintf("trip %d: %s %s -> %s", c.Trip, c.Passenger, c.OldLabel, c.NewLabel)
+}
+
+// Билет, место которого не подходит для другого самолёта, или билет без места
+// (Seat = 0), которому не хватает лимита билетов другого самолёта
+type SeatConflict struct {
+	Trip      int
+	Ticket    int
+	Passenger string
+	Seat      int
+	Label     string
+	Reason    string
+}
+
+func (c SeatConflict) String() string {
+	if c.Seat == 0 {
+		return fmt.Sprintf("trip %d: %s ticket %d: %s", c.Trip, c.Passenger, c.Ticket, c.Reason)
+	}
+	return fmt.Sprintf("trip %d: %s seat %s: %s", c.Trip, c.Passenger, c.Label, c.Reason)
+}
+
+type SeatConflictError struct {
+	Conflicts []SeatConflict
+}
+
+func (e *SeatConflictError) Error() string {
+	parts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		parts[i] = c.String()
+	}
+	return ErrSeatConflict.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *SeatConflictError) Unwrap() error {
+	return ErrSeatConflict
+}
+
+// Проверяет места билетов поездки по схеме салона самолёта planeID и, если
+// remap задан, подбирает новые места билетам, чьих мест в самолёте нет
+// или они заблокированы. Билеты сверх лимита билетов самолёта (места плюс
+// перебронирование) - конфликты, в них попадают билеты без мест, проданные последними.
+// Пересадка записывается в базу сразу, самолёт поездки не меняется.
+// Удерживаемые места, которых нет в самолёте, освобождаются.
+func (db *AeroDB) refitTrip(tx *sql.Tx, tripID, planeID int, remap SeatRemap, now time.Time) ([]SeatChange, []SeatConflict, error) {
+	oldMap, err := tripSeatMap(tx, tripID)
+	if err != nil {
+		return nil, nil, err
+	}
+	newMap, err := planeSeatMap(tx, planeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	valid := func(seat int) bool {
+		return seat >= 1 && seat <= len(newMap) && !newMap[seat-1].Attrs.Has(SeatBlocked)
+	}
+	label := func(seatMap []SeatInfo, seat int) string {
+		if seat >= 1 && seat <= len(seatMap) {
+			return seatMap[seat-1].Label()
+		}
+		return fmt.Sprint(seat)
+	}
+
+	type ticket struct {
+		id        int
+		passenger string
+		seat      int
+	}
+	rows, err := tx.Query(`SELECT k.id, IFNULL(p.name, ''), k.place FROM Taken k
+		JOIN Passenger p ON p.id = k.passenger_id
+		WHERE k.trip_id = ? AND k.place IS NOT NULL ORDER BY k.place`, tripID)
+	if err != nil {
+		return nil, nil, dbError(err)
+	}
+	var tickets []ticket
+	for rows.Next() {
+		var t ticket
+		if err = rows.Scan(&t.id, &t.passenger, &t.seat); err != nil {
+			rows.Close()
+			return nil, nil, dbError(err)
+		}
+		tickets = append(tickets, t)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, nil, dbError(err)
+	}
+
+	held, err := queryInts(tx, "SELECT place FROM Hold WHERE trip_id = ? AND "+activeHold, tripID, now)
+	if err != nil {
+		return nil, nil, dbError(err)
+	}
+	used := make(map[int]bool)
+	for _, seat := range held {
+		used[seat] = true
+	}
+	var misplaced []ticket
+	for _, t := range tickets {
+		if valid(t.seat) {
+			used[t.seat] = true
+		} else {
+			misplaced = append(misplaced, t)
+		}
+	}
+
+	// Первое свободное место, подходящее под условие
+	pick := func(match func(s SeatInfo) bool) int {
+		for _, s := range newMap {
+			if valid(s.Number) && !used[s.Number] && match(s) {
+				return s.Number
+			}
+		}
+		return 0
+	}
+	anySeat := func(SeatInfo) bool { return true }
+
+	var changes []SeatChange
+	var conflicts []SeatConflict
+	for _, t := range misplaced {
+		reason := fmt.Sprintf("no seat %s on the plane", label(oldMap, t.seat))
+		if t.seat <= len(newMap) {
+			reason = fmt.Sprintf("seat %s is blocked", label(newMap, t.seat))
+		}
+		seat := 0
+		switch remap {
+		case RemapByLabel:
+			old := label(oldMap, t.seat)
+			if seat = pick(func(s SeatInfo) bool { return s.Label() == old }); seat == 0 && t.seat <= len(oldMap) {
+				cabin := oldMap[t.seat-1].Cabin
+				seat = pick(func(s SeatInfo) bool { return s.Cabin == cabin })
+			}
+			if seat == 0 {
+				seat = pick(anySeat)
+			}
+		case RemapByOrder:
+			seat = pick(anySeat)
+		}
+		if seat == 0 {
+			if remap != RemapNone {
+				reason += ", no free seat to move to"
+			}
+			conflicts = append(conflicts, SeatConflict{
+				Trip: tripID, Ticket: t.id, Passenger: t.passenger,
+				Seat: t.seat, Label: label(oldMap, t.seat), Reason: reason,
+			})
+			continue
+		}
+		used[seat] = true
+		changes = append(changes, SeatChange{
+			Trip: tripID, Ticket: t.id, Passenger: t.passenger,
+			OldSeat: t.seat, OldLabel: label(oldMap, t.seat),
+			NewSeat: seat, NewLabel: label(newMap, seat),
+		})
+	}
+
+	limit, sold, err := db.planeTicketLimit(tx, tripID, planeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sold > limit {
+		rows, err := tx.Query(`SELECT k.id, IFNULL(p.name, '') FROM Taken k
+			JOIN Passenger p ON p.id = k.passenger_id
+			WHERE k.trip_id = ? AND k.place IS NULL AND k.status <> ?
+			ORDER BY k.id DESC LIMIT ?`, tripID, StatusNoShow, sold-limit)
+		if err != nil {
+			return nil, nil, dbError(err)
+		}
+		var excess []SeatConflict
+		for rows.Next() {
+			c := SeatConflict{Trip: tripID, Reason: fmt.Sprintf("%d tickets sold, limit %d", sold, limit)}
+			if err = rows.Scan(&c.Ticket, &c.Passenger); err != nil {
+				rows.Close()
+				return nil, nil, dbError(err)
+			}
+			excess = append([]SeatConflict{c}, excess...)
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			return nil, nil, dbError(err)
+		}
+		conflicts = append(conflicts, excess...)
+	}
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	for _, c := range changes {
+		if _, err = tx.Exec("UPDATE Taken SET place = ? WHERE id = ?", c.NewSeat, c.Ticket); err != nil {
+			return nil, nil, dbError(err)
+		}
+	}
+	for _, seat := range held {
+		if !valid(seat) {
+			if _, err = tx.Exec("DELETE FROM Hold WHERE trip_id = ? AND place = ?", tripID, seat); err != nil {
+				return nil, nil, dbError(err)
+			}
+		}
+	}
+	return changes, nil, nil
+}
+
+// Меняет самолёт поездки на newPlane. Новый самолёт должен быть свободен во время
+// поездки, поездка проверяется по правилам расписания из поля Rules. Места билетов
+// проверяются по схеме салона нового самолёта: если каких-то мест нет, возвращается
+// *SeatConflictError, либо пассажиры пересаживаются согласно remap. Билеты сверх
+// лимита нового самолёта - всегда *SeatConflictError.
+// Возвращает пассажиров, чьи места изменились.
+func (db *AeroDB) ReassignPlane(tripID int, newPlane string, remap SeatRemap) ([]SeatChange, error) {
+	changes := []SeatChange{}
+	err := db.inTx(func(tx *sql.Tx) error {
+		trips, err := queryTrips(tx, "SELECT "+tripColumns+" FROM Trip WHERE id = ?", tripID)
+		if err == ErrEmpty {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		trip := trips[0]
+		if err = checkBookable(tx, tripID); err != nil {
+			return err
+		}
+		planeID, err := idByName(tx, "Plane", newPlane)
+		if err != nil {
+			return err
+		}
+		if planeID == trip.plane {
+			return nil
+		}
+		if err = checkPlaneFree(tx, planeID, trip.timeOut, trip.timeIn, tripID); err != nil {
+			return err
+		}
+
+		moved, conflicts, err := db.refitTrip(tx, tripID, planeID, remap, db.now())
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &SeatConflictError{Conflicts: conflicts}
+		}
+		changes = append(changes, moved...)
+		if _, err = tx.Exec("UPDATE Trip SET plane_id = ? WHERE id = ?", planeID, tripID); err != nil {
+			return dbError(err)
+		}
+		if db.Rules != nil {
+			trip.plane = planeID
+			return db.Rules.checkTrip(tx, trip)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,185 @@
+package aerodb
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RouteOrder int
+
+const (
+	// Меньше пересадок, затем раньше прибытие, затем короче путь
+	RouteByLegs RouteOrder = iota
+	// Раньше прибытие, затем меньше пересадок, затем короче путь
+	RouteByArrival
+	// Короче путь, затем меньше пересадок, затем раньше прибытие
+	RouteByDuration
+)
+
+const DefaultMaxLegs = 3
+
+// Параметры поиска маршрутов. Нулевые значения: пересадка любой длины,
+// не больше DefaultMaxLegs поездок, сортировка RouteByLegs, без ограничения количества.
+type RouteOptions struct {
+	MinConnection time.Duration
+	MaxConnection time.Duration
+	MaxLegs       int
+	Order         RouteOrder
+	Limit         int
+}
+
+// Поездка маршрута и количество свободных мест в ней
+type Leg struct {
+	Trip      Trip
+	FreeSeats int
+}
+
+type Itinerary struct {
+	Legs []Leg
+}
+
+func (it Itinerary) Departure() time.Time {
+	return it.Legs[0].Trip.timeOut
+}
+
+func (it Itinerary) Arrival() time.Time {
+	return it.Legs[len(it.Legs)-1].Trip.timeIn
+}
+
+func (it Itinerary) Duration() time.Duration {
+	return it.Arrival().Sub(it.Departure())
+}
+
+// Количество мест, доступных на всём маршруте
+func (it Itinerary) FreeSeats() int {
+	res := it.Legs[0].FreeSeats
+	for _, leg := range it.Legs[1:] {
+		if leg.FreeSeats < res {
+			res = leg.FreeSeats
+		}
+	}
+	return res
+}
+
+func (it Itinerary) String() string {
+	parts := make([]string, len(it.Legs))
+	for i, leg := range it.Legs {
+		parts[i] = strconv.Itoa(leg.Trip.id)
+	}
+	return it.Legs[0].Trip.townOut + " -> " + it.Legs[len(it.Legs)-1].Trip.townIn +
+		": trips " + strings.Join(parts, ", ")
+}
+
+// Ищет маршруты из from в to, в том числе с пересадками. Учитываются поездки,
+// на которые ещё можно занять места.
+func (db *AeroDB) FindRoutes(from, to string, opts RouteOptions) ([]Itinerary, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	if opts.MaxLegs <= 0 {
+		opts.MaxLegs = DefaultMaxLegs
+	}
+
+	trips, err := queryTrips(db.db, "SELECT "+tripColumns+" FROM Trip WHERE state IN (?, ?)", StateScheduled, StateBoarding)
+	if err != nil {
+		return nil, err
+	}
+	sortByDeparture(trips)
+	byTown := make(map[string][]Trip)
+	for _, t := range trips {
+		byTown[t.townOut] = append(byTown[t.townOut], t)
+	}
+
+	// Поиск в глубину по графу поездок: из поездки можно пересесть на поездку,
+	// вылетающую из города прибытия в допустимое время
+	var routes [][]Trip
+	visited := map[string]bool{from: true}
+	var path []Trip
+	var walk func(town string, arrival time.Time)
+	walk = func(town string, arrival time.Time) {
+		for _, t := range byTown[town] {
+			if len(path) > 0 {
+				wait := t.timeOut.Sub(arrival)
+				if wait < opts.MinConnection || (opts.MaxConnection > 0 && wait > opts.MaxConnection) {
+					continue
+				}
+			}
+			if visited[t.townIn] {
+				continue
+			}
+			path = append(path, t)
+			if t.townIn == to {
+				routes = append(routes, append([]Trip(nil), path...))
+			} else if len(path) < opts.MaxLegs {
+				visited[t.townIn] = true
+				walk(t.townIn, t.timeIn)
+				visited[t.townIn] = false
+			}
+			path = path[:len(path)-1]
+		}
+	}
+	walk(from, time.Time{})
+
+	if len(routes) == 0 {
+		return nil, ErrEmpty
+	}
+
+	now := db.now()
+	free := make(map[int]int)
+	res := make([]Itinerary, len(routes))
+	for i, route := range routes {
+		legs := make([]Leg, len(route))
+		for j, t := range route {
+			n, ok := free[t.id]
+			if !ok {
+				_, left, err := db.openSeats(db.db, t.id, now)
+				if err != nil {
+					return nil, err
+				}
+				n = left
+				free[t.id] = n
+			}
+			legs[j] = Leg{Trip: t, FreeSeats: n}
+		}
+		res[i] = Itinerary{Legs: legs}
+	}
+
+	sortItineraries(res, opts.Order)
+	if opts.Limit > 0 && len(res) > opts.Limit {
+		res = res[:opts.Limit]
+	}
+	return res, nil
+}
+
+func sortItineraries(its []Itinerary, order RouteOrder) {
+	byLegs := func(a, b Itinerary) int { return len(a.Legs) - len(b.Legs) }
+	byArrival := func(a, b Itinerary) int { return a.Arrival().Compare(b.Arrival()) }
+	byDuration := func(a, b Itinerary) int {
+		switch da, db := a.Duration(), b.Duration(); {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		}
+		return 0
+	}
+
+	keys := []func(a, b Itinerary) int{byLegs, byArrival, byDuration}
+	switch order {
+	case RouteByArrival:
+		keys = []func(a, b Itinerary) int{byArrival, byLegs, byDuration}
+	case RouteByDuration:
+		keys = []func(a, b Itinerary) int{byDuration, byLegs, byArrival}
+	}
+
+	sort.SliceStable(its, func(i, j int) bool {
+		for _, key := range keys {
+			if c := key(its[i], its[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,215 @@
+package aerodb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Самолёт уже выполняет другую поездку в это время
+type PlaneBusyError struct {
+	Plane, Trip int
+}
+
+func (e *PlaneBusyError) Error() string {
+	return fmt.Sprintf("%v: plane %d is already on trip %d", ErrPlaneBusy, e.Plane, e.Trip)
+}
+
+func (e *PlaneBusyError) Unwrap() error {
+	return ErrPlaneBusy
+}
+
+// Неотменённые поездки самолёта в порядке вылета
+func planeTrips(q querier, planeID int) ([]Trip, error) {
+	trips, err := queryTrips(q, "SELECT "+tripColumns+" FROM Trip WHERE plane_id = ? AND state <> ?", planeID, StateCancelled)
+	if err == ErrEmpty {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sortByDeparture(trips)
+	return trips, nil
+}
+
+// Проверяет, что самолёт свободен в промежутке [timeOut, timeIn].
+// Поездка exceptTrip не учитывается.
+func checkPlaneFree(q querier, planeID int, timeOut, timeIn time.Time, exceptTrip int) error {
+	trips, err := planeTrips(q, planeID)
+	if err != nil {
+		return err
+	}
+	for _, t := range trips {
+		if t.id != exceptTrip && t.timeOut.Before(timeIn) && timeOut.Before(t.timeIn) {
+			return &PlaneBusyError{Plane: planeID, Trip: t.id}
+		}
+	}
+	return nil
+}
+
+type ScheduleRule string
+
+const (
+	// Следующая поездка самолёта начинается в городе прибытия предыдущей
+	RulePositioning ScheduleRule = "positioning"
+	// Между поездками самолёта проходит не меньше минимального времени разворота
+	RuleTurnaround ScheduleRule = "turnaround"
+	// Поездки самолёта не пересекаются по времени
+	RuleOverlap ScheduleRule = "overlap"
+)
+
+// Правила составления расписания. Время разворота берётся по названию
+// самолёта, затем по названию компании-владельца самолёта, затем Turnaround.
+type ScheduleRules struct {
+	Positioning       bool
+	Turnaround        time.Duration
+	PlaneTurnaround   map[string]time.Duration
+	CompanyTurnaround map[string]time.Duration
+}
+
+func (r *ScheduleRules) turnaround(plane, company string) time.Duration {
+	if d, ok := r.PlaneTurnaround[plane]; ok {
+		return d
+	}
+	if d, ok := r.CompanyTurnaround[company]; ok {
+		return d
+	}
+	return r.Turnaround
+}
+
+// Нарушение правила расписания поездкой Trip, Previous - предыдущая поездка самолёта
+type RuleViolation struct {
+	Rule     ScheduleRule
+	Plane    int
+	Trip     int
+	Previous int
+	Detail   string
+}
+
+func (v RuleViolation) String() string {
+	return fmt.Sprintf("%s: trip %d after trip %d of plane %d: %s", v.Rule, v.Trip, v.Previous, v.Plane, v.Detail)
+}
+
+type ScheduleError struct {
+	Violations []RuleViolation
+}
+
+func (e *ScheduleError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.String()
+	}
+	return ErrScheduleRule.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *ScheduleError) Unwrap() error {
+	return ErrScheduleRule
+}
+
+// Проверяет пару последовательных поездок самолёта
+func (r *ScheduleRules) checkPair(prev, next Trip, turnaround time.Duration) []RuleViolation {
+	var res []RuleViolation
+	violation := func(rule ScheduleRule, format string, args ...interface{}) {
+		res = append(res, RuleViolation{
+			Rule:     rule,
+			Plane:    next.plane,
+			Trip:     next.id,
+			Previous: prev.id,
+			Detail:   fmt.Sprintf(format, args...),
+		})
+	}
+
+	gap := next.timeOut.Sub(prev.timeIn)
+	if gap < 0 {
+		violation(RuleOverlap, "departs %v before previous arrival", -gap)
+		return res
+	}
+	if r.Positioning && prev.townIn != next.townOut {
+		violation(RulePositioning, "departs from %s, plane is in %s", next.townOut, prev.townIn)
+	}
+	if gap < turnaround {
+		violation(RuleTurnaround, "turnaround %v, minimum %v", gap, turnaround)
+	}
+	return res
+}
+
+// Названия самолёта и его компании
+func planeNames(q querier, planeID int) (plane, company string, err error) {
+	err = q.QueryRow(`SELECT p.name, IFNULL(c.name, '') FROM Plane p
+		LEFT JOIN Company c ON c.id = p.company_id
+		WHERE p.id = ?`, planeID).Scan(&plane, &company)
+	if err != nil {
+		return "", "", dbError(err)
+	}
+	return plane, company, nil
+}
+
+// Проверяет поездку trip, уже записанную в базу, относительно соседних поездок самолёта
+func (r *ScheduleRules) checkTrip(q querier, trip Trip) error {
+	trips, err := planeTrips(q, trip.plane)
+	if err != nil {
+		return err
+	}
+	plane, company, err := planeNames(q, trip.plane)
+	if err != nil {
+		return err
+	}
+	turnaround := r.turnaround(plane, company)
+
+	var violations []RuleViolation
+	last := -1 // Предыдущая поездка с самым поздним прибытием
+	for i, t := range trips {
+		if t.id != trip.id {
+			if last < 0 || t.timeIn.After(trips[last].timeIn) {
+				last = i
+			}
+			continue
+		}
+		if last >= 0 {
+			violations = append(violations, r.checkPair(trips[last], t, turnaround)...)
+		}
+		if i+1 < len(trips) {
+			violations = append(violations, r.checkPair(t, trips[i+1], turnaround)...)
+		}
+	}
+	if len(violations) > 0 {
+		return &ScheduleError{Violations: violations}
+	}
+	return nil
+}
+
+// Проверяет по правилам rules все неотменённые поездки в базе.
+// Возвращает найденные нарушения, пустой слайс - расписание корректно.
+func (db *AeroDB) CheckSchedule(rules ScheduleRules) ([]RuleViolation, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+
+	planes, err := queryInts(db.db, "SELECT DISTINCT plane_id FROM Trip WHERE plane_id IS NOT NULL ORDER BY plane_id")
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	var res []RuleViolation
+	for _, planeID := range planes {
+		trips, err := planeTrips(db.db, planeID)
+		if err != nil {
+			return nil, err
+		}
+		plane, company, err := planeNames(db.db, planeID)
+		if err != nil {
+			return nil, err
+		}
+		turnaround := rules.turnaround(plane, company)
+		last := 0
+		// Поездка сравнивается с поездкой, прибывающей позже всех предыдущих:
+		// длинная поездка может перекрывать и несоседние поездки
+		for i := 1; i < len(trips); i++ {
+			res = append(res, rules.checkPair(trips[last], trips[i], turnaround)...)
+			if trips[i].timeIn.After(trips[last].timeIn) {
+				last = i
+			}
+		}
+	}
+	return res, nil
+}
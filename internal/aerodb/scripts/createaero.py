@@ -21,48 +21,14 @@ if not os.path.exists(os.path.dirname(FILENAME)):
     print("Incorrect path to file")
     sys.exit(2)
 
-if (os.path.exists(FILENAME)):
-    os.remove(FILENAME)
+# Схема базы создаётся миграциями AeroDB (migrations/*.sql),
+# скрипт только заполняет уже созданную базу тестовыми данными
+if not os.path.exists(FILENAME):
+    print("Database file does not exist")
+    sys.exit(3)
 
 db = sqlite3.connect(FILENAME)
 
-db.execute("""CREATE TABLE IF NOT EXISTS Trip (
-           id INTEGER primary key,
-           company_id INTEGER,
-           plane_id INTEGER,
-           time_out TIMESTAMP,
-           time_in TIMESTAMP,
-           town_out VARCHAR,
-           town_in VARCHAR
-)""")
-
-db.execute("""CREATE TABLE IF NOT EXISTS Plane (
-           id INTEGER primary key,
-           name VARCHAR UNIQUE,
-           company_id INTEGER,
-           seats INTEGER
-)""")
-
-db.execute("""CREATE TABLE IF NOT EXISTS Company (
-           id INTEGER primary key,
-           name VARCHAR UNIQUE
-)""")
-
-db.execute("""CREATE TABLE IF NOT EXISTS Passenger (
-           id INTEGER primary key,
-           name VARCHAR UNIQUE
-)""")
-
-db.execute("""CREATE TABLE IF NOT EXISTS Taken (
-           id INTEGER primary key,
-           trip_id INTEGER,
-           passenger_id INTEGER,
-           place INTEGER
-)""")
-
-
-
-
 db.executemany("INSERT INTO Passenger(name) VALUES (?)", data.PASSENGERS)
 db.executemany("INSERT INTO Company(name) VALUES (?)", data.COMPANIES)
 db.executemany("""INSERT INTO Plane(name, company_id, seats) 
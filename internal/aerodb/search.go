@@ -0,0 +1,152 @@
+package aerodb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TripOrder int
+
+const (
+	TripsByID TripOrder = iota
+	TripsByDeparture
+	TripsByArrival
+	TripsByDuration
+)
+
+// Условия поиска поездок. Пустые поля не ограничивают поиск,
+// Limit = 0 - без ограничения количества.
+type TripQuery struct {
+	From, To                  string
+	DepartAfter, DepartBefore time.Time
+	ArriveAfter, ArriveBefore time.Time
+	Company                   string
+	Plane                     string
+	MinFreeSeats              int
+	State                     TripState
+
+	OrderBy TripOrder
+	Desc    bool
+	Limit   int
+	Offset  int
+}
+
+// Страница результатов поиска и общее количество найденных поездок
+type TripPage struct {
+	Trips []Trip
+	Total int
+}
+
+func (q TripQuery) where(conn querier, now time.Time, overbooking map[string]int) (string, []interface{}, error) {
+	var conds []string
+	var args []interface{}
+	add := func(cond string, arg ...interface{}) {
+		conds = append(conds, cond)
+		args = append(args, arg...)
+	}
+	// Время хранится с разными часовыми поясами, сравнение через julianday
+	addTime := func(column, op string, t time.Time) {
+		if !t.IsZero() {
+			add("julianday("+column+") "+op+" julianday(?)", t)
+		}
+	}
+
+	if q.From != "" {
+		add("town_out = ?", q.From)
+	}
+	if q.To != "" {
+		add("town_in = ?", q.To)
+	}
+	addTime("time_out", ">=", q.DepartAfter)
+	addTime("time_out", "<=", q.DepartBefore)
+	addTime("time_in", ">=", q.ArriveAfter)
+	addTime("time_in", "<=", q.ArriveBefore)
+	// Неизвестная компания или самолёт - ошибка, а не пустой результат
+	if q.Company != "" {
+		companyID, err := idByName(conn, "Company", q.Company)
+		if err != nil {
+			return "", nil, err
+		}
+		add("company_id = ?", companyID)
+	}
+	if q.Plane != "" {
+		planeID, err := idByName(conn, "Plane", q.Plane)
+		if err != nil {
+			return "", nil, err
+		}
+		add("plane_id = ?", planeID)
+	}
+	if q.MinFreeSeats > 0 {
+		left, leftArgs := ticketsLeftSQL(now, overbooking)
+		add(left+" >= ?", append(leftArgs, q.MinFreeSeats)...)
+	}
+	if q.State != "" {
+		if !q.State.Valid() {
+			return "", nil, fmt.Errorf("%w: unknown state %q", ErrTripState, q.State)
+		}
+		add("state = ?", q.State)
+	}
+
+	if len(conds) == 0 {
+		return "", nil, nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args, nil
+}
+
+func (q TripQuery) orderBy() string {
+	var key string
+	switch q.OrderBy {
+	case TripsByDeparture:
+		key = "julianday(time_out)"
+	case TripsByArrival:
+		key = "julianday(time_in)"
+	case TripsByDuration:
+		key = "julianday(time_in) - julianday(time_out)"
+	default:
+		key = "id"
+	}
+	if q.Desc {
+		return " ORDER BY " + key + " DESC, id DESC"
+	}
+	return " ORDER BY " + key + ", id"
+}
+
+// Ищет поездки по условиям q. Возвращает страницу с Limit поездками,
+// начиная с Offset, и общее количество подходящих поездок.
+func (db *AeroDB) SearchTrips(q TripQuery) (TripPage, error) {
+	if db.db == nil {
+		return TripPage{}, ErrNotOpened
+	}
+	where, args, err := q.where(db.db, db.now(), db.Overbooking)
+	if err != nil {
+		return TripPage{}, err
+	}
+
+	var page TripPage
+	err = db.db.QueryRow("SELECT COUNT(*) FROM Trip"+where, args...).Scan(&page.Total)
+	if err != nil {
+		return TripPage{}, dbError(err)
+	}
+	if page.Total == 0 {
+		return TripPage{}, ErrEmpty
+	}
+
+	it, err := db.IterSearchTrips(q)
+	if err != nil {
+		return TripPage{}, err
+	}
+	page.Trips, err = it.collect()
+	if err != nil && err != ErrEmpty {
+		return TripPage{}, err
+	}
+	return page, nil
+}
+
+// LIMIT для SQL: -1 - без ограничения
+func (q TripQuery) limit() int {
+	if q.Limit <= 0 {
+		return -1
+	}
+	return q.Limit
+}
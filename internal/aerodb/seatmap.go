@@ -0,0 +1,368 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Cabin string
+
+const (
+	CabinEconomy  Cabin = "economy"
+	CabinPremium  Cabin = "premium"
+	CabinBusiness Cabin = "business"
+	CabinFirst    Cabin = "first"
+)
+
+func (c Cabin) Valid() bool {
+	switch c {
+	case CabinEconomy, CabinPremium, CabinBusiness, CabinFirst:
+		return true
+	}
+	return false
+}
+
+// Признаки места, хранятся битовой маской
+type SeatAttrs int
+
+const (
+	// Место не продаётся
+	SeatBlocked SeatAttrs = 1 << iota
+	SeatExit
+	SeatAisle
+	SeatWindow
+)
+
+var seatAttrNames = []string{"blocked", "exit", "aisle", "window"}
+
+func (a SeatAttrs) Has(attrs SeatAttrs) bool {
+	return a&attrs == attrs
+}
+
+func (a SeatAttrs) String() string {
+	var names []string
+	for i, name := range seatAttrNames {
+		if a&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// Место в салоне: номер места в поездках (Taken.place) и его обозначение
+type SeatInfo struct {
+	Number int
+	Row    int
+	Letter string
+	Cabin  Cabin
+	Attrs  SeatAttrs
+}
+
+// Обозначение места, например "12C"
+func (s SeatInfo) Label() string {
+	return strconv.Itoa(s.Row) + s.Letter
+}
+
+func (s SeatInfo) String() string {
+	res := s.Label() + " " + string(s.Cabin)
+	if s.Attrs != 0 {
+		res += " " + s.Attrs.String()
+	}
+	return res
+}
+
+// Салон одного класса. Letters - буквы мест ряда слева направо,
+// пробел обозначает проход, например "ABC DEF".
+type CabinLayout struct {
+	Cabin    Cabin
+	Rows     int
+	Letters  string
+	ExitRows []int
+}
+
+// Схема салона: классы идут подряд, ряды нумеруются с 1 сквозь все классы.
+// Места нумеруются в том же порядке: по рядам, в ряду по буквам.
+type SeatLayout struct {
+	Cabins  []CabinLayout
+	Blocked []string
+}
+
+func (l SeatLayout) seats() ([]SeatInfo, error) {
+	var res []SeatInfo
+	byLabel := make(map[string]int)
+	row := 0
+	for _, c := range l.Cabins {
+		if !c.Cabin.Valid() {
+			return nil, fmt.Errorf("%w: unknown cabin %q", ErrSeatLayout, c.Cabin)
+		}
+		if c.Rows <= 0 {
+			return nil, fmt.Errorf("%w: %s has %d rows", ErrSeatLayout, c.Cabin, c.Rows)
+		}
+		letters := strings.TrimSpace(c.Letters)
+		if letters == "" || strings.Contains(letters, "  ") {
+			return nil, fmt.Errorf("%w: bad letters %q", ErrSeatLayout, c.Letters)
+		}
+		exit := make(map[int]bool)
+		for _, r := range c.ExitRows {
+			if r <= row || r > row+c.Rows {
+				return nil, fmt.Errorf("%w: exit row %d is not in %s", ErrSeatLayout, r, c.Cabin)
+			}
+			exit[r] = true
+		}
+
+		for i := 0; i < c.Rows; i++ {
+			row++
+			for j, ch := range letters {
+				if ch == ' ' {
+					continue
+				}
+				if ch < 'A' || ch > 'Z' {
+					return nil, fmt.Errorf("%w: bad letters %q", ErrSeatLayout, c.Letters)
+				}
+				seat := SeatInfo{Number: len(res) + 1, Row: row, Letter: string(ch), Cabin: c.Cabin}
+				if j == 0 || j == len(letters)-1 {
+					seat.Attrs |= SeatWindow
+				}
+				if (j > 0 && letters[j-1] == ' ') || (j+1 < len(letters) && letters[j+1] == ' ') {
+					seat.Attrs |= SeatAisle
+				}
+				if exit[row] {
+					seat.Attrs |= SeatExit
+				}
+				if _, ok := byLabel[seat.Label()]; ok {
+					return nil, fmt.Errorf("%w: seat %s repeats", ErrSeatLayout, seat.Label())
+				}
+				byLabel[seat.Label()] = len(res)
+				res = append(res, seat)
+			}
+		}
+	}
+
+	for _, label := range l.Blocked {
+		i, ok := byLabel[strings.ToUpper(label)]
+		if !ok {
+			return nil, fmt.Errorf("%w: blocked seat %q is not in layout", ErrSeatLayout, label)
+		}
+		res[i].Attrs |= SeatBlocked
+	}
+	return res, nil
+}
+
+// Схема по умолчанию: один эконом-класс, ряды по шесть мест "ABC DEF"
+func defaultSeatMap(seats int) []SeatInfo {
+	layout := CabinLayout{Cabin: CabinEconomy, Rows: (seats + 5) / 6, Letters: "ABC DEF"}
+	res, _ := SeatLayout{Cabins: []CabinLayout{layout}}.seats()
+	return res[:seats]
+}
+
+func planeSeatMap(q querier, planeID int) ([]SeatInfo, error) {
+	rows, err := q.Query("SELECT place, row_num, letter, cabin, attrs FROM Seat WHERE plane_id = ? ORDER BY place", planeID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer rows.Close()
+
+	var res []SeatInfo
+	for rows.Next() {
+		var s SeatInfo
+		if err = rows.Scan(&s.Number, &s.Row, &s.Letter, &s.Cabin, &s.Attrs); err != nil {
+			return nil, dbError(err)
+		}
+		res = append(res, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, dbError(err)
+	}
+	if len(res) > 0 {
+		return res, nil
+	}
+
+	var seats int
+	err = q.QueryRow("SELECT seats FROM Plane WHERE id = ?", planeID).Scan(&seats)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return defaultSeatMap(seats), nil
+}
+
+func tripSeatMap(q querier, tripID int) ([]SeatInfo, error) {
+	var planeID int
+	err := q.QueryRow("SELECT plane_id FROM Trip WHERE id = ?", tripID).Scan(&planeID)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return planeSeatMap(q, planeID)
+}
+
+// Номер места поездки по обозначению, ErrSeatRange если такого места нет
+func seatNumber(q querier, tripID int, label string) (int, error) {
+	seatMap, err := tripSeatMap(q, tripID)
+	if err != nil {
+		return 0, err
+	}
+	label = strings.ToUpper(strings.TrimSpace(label))
+	for _, s := range seatMap {
+		if s.Label() == label {
+			return s.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: no seat %q", ErrSeatRange, label)
+}
+
+// Задаёт схему салона самолёта, количество мест самолёта становится равным
+// количеству мест в схеме. Пустая схема возвращает схему по умолчанию.
+// Нельзя убрать или заблокировать места, занятые в незавершённых поездках самолёта.
+func (db *AeroDB) SetSeatLayout(plane string, layout SeatLayout) error {
+	seatMap, err := layout.seats()
+	if err != nil {
+		return err
+	}
+	return db.inTx(func(tx *sql.Tx) error {
+		planeID, err := idByName(tx, "Plane", plane)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec("DELETE FROM Seat WHERE plane_id = ?", planeID); err != nil {
+			return dbError(err)
+		}
+		if len(seatMap) == 0 {
+			return nil
+		}
+
+		// Места прибывших и отменённых поездок уже не используются
+		var outside int
+		err = tx.QueryRow(`SELECT COUNT(*) FROM Taken WHERE place > ?
+			AND trip_id IN (SELECT id FROM Trip WHERE plane_id = ? AND state NOT IN (?, ?))`,
+			len(seatMap), planeID, StateArrived, StateCancelled).Scan(&outside)
+		if err != nil {
+			return dbError(err)
+		}
+		if outside > 0 {
+			return fmt.Errorf("%w: %d taken seats are beyond %d seats of layout", ErrSeatRange, outside, len(seatMap))
+		}
+
+		now := db.now()
+		for _, s := range seatMap {
+			if s.Attrs.Has(SeatBlocked) {
+				taken, err := exists(tx, `SELECT 1 FROM Trip t WHERE t.plane_id = ? AND t.state NOT IN (?, ?) AND (
+					EXISTS (SELECT 1 FROM Taken WHERE trip_id = t.id AND place = ?) OR
+					EXISTS (SELECT 1 FROM Hold WHERE trip_id = t.id AND place = ? AND `+activeHold+`))`,
+					planeID, StateArrived, StateCancelled, s.Number, s.Number, now)
+				if err != nil {
+					return err
+				}
+				if taken {
+					return fmt.Errorf("%w: cannot block seat %s", ErrAlreadyTaken, s.Label())
+				}
+			}
+			_, err = tx.Exec("INSERT INTO Seat(plane_id, place, row_num, letter, cabin, attrs) VALUES (?, ?, ?, ?, ?, ?)",
+				planeID, s.Number, s.Row, s.Letter, s.Cabin, s.Attrs)
+			if err != nil {
+				return dbError(err)
+			}
+		}
+		if _, err = tx.Exec("UPDATE Plane SET seats = ? WHERE id = ?", len(seatMap), planeID); err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+// Схема салона самолёта
+func (db *AeroDB) GetSeatMap(plane string) ([]SeatInfo, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	planeID, err := idByName(db.db, "Plane", plane)
+	if err != nil {
+		return nil, err
+	}
+	return planeSeatMap(db.db, planeID)
+}
+
+// Номер места поездки по обозначению вида "12C"
+func (db *AeroDB) SeatNumber(tripID int, label string) (int, error) {
+	if db.db == nil {
+		return 0, ErrNotOpened
+	}
+	return seatNumber(db.db, tripID, label)
+}
+
+// Отбор мест: класс (пустой - любой) и признаки, которые должны быть у места
+type SeatFilter struct {
+	Cabin Cabin
+	Attrs SeatAttrs
+}
+
+func (f SeatFilter) match(s SeatInfo) bool {
+	return (f.Cabin == "" || s.Cabin == f.Cabin) && s.Attrs.Has(f.Attrs)
+}
+
+// Свободные места поездки, подходящие под фильтр
+func (db *AeroDB) GetFreeSeatsBy(tripID int, filter SeatFilter) ([]SeatInfo, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	seatMap, err := tripSeatMap(db.db, tripID)
+	if err != nil {
+		return nil, err
+	}
+	free, _, err := db.openSeats(db.db, tripID, db.now())
+	if err != nil {
+		return nil, err
+	}
+
+	var res []SeatInfo
+	for _, n := range free {
+		if n <= len(seatMap) && filter.match(seatMap[n-1]) {
+			res = append(res, seatMap[n-1])
+		}
+	}
+	if len(res) == 0 {
+		return nil, ErrEmpty
+	}
+	return res, nil
+}
+
+func (db *AeroDB) TakeSeatLabel(tripID int, passenger string, label string) error {
+	return db.takeSeatLabel(tripID, byName(passenger), label)
+}
+
+func (db *AeroDB) TakeSeatLabelByID(tripID int, passengerID int, label string) error {
+	return db.takeSeatLabel(tripID, byID(passengerID), label)
+}
+
+func (db *AeroDB) takeSeatLabel(tripID int, passenger passengerRef, label string) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		seat, err := seatNumber(tx, tripID, label)
+		if err != nil {
+			return err
+		}
+		return db.takeSeat(tx, tripID, passenger, seat, false)
+	})
+}
+
+func (db *AeroDB) HoldSeatLabel(tripID int, label string, ttl time.Duration) (string, error) {
+	var token string
+	err := db.inTx(func(tx *sql.Tx) error {
+		seat, err := seatNumber(tx, tripID, label)
+		if err != nil {
+			return err
+		}
+		token, err = holdSeat(tx, tripID, seat, ttl, db.now())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
@@ -0,0 +1,269 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Количество мест в самолёте, выполняющем поездку
+func tripSeats(q querier, tripID int) (int, error) {
+	var seats int
+	err := q.QueryRow(`SELECT p.seats FROM Trip t
+		JOIN Plane p ON p.id = t.plane_id
+		WHERE t.id = ?`, tripID).Scan(&seats)
+	if err == sql.ErrNoRows {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, dbError(err)
+	}
+	return seats, nil
+}
+
+// Места можно занимать только до вылета поездки
+func checkBookable(q querier, tripID int) error {
+	state, err := tripState(q, tripID)
+	if err != nil {
+		return err
+	}
+	if state != StateScheduled && state != StateBoarding {
+		return fmt.Errorf("%w: trip %d is %s", ErrTripState, tripID, state)
+	}
+	return nil
+}
+
+func (db *AeroDB) TakeSeat(tripID int, passenger string, seat int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		return db.takeSeat(tx, tripID, byName(passenger), seat, false)
+	})
+}
+
+func (db *AeroDB) TakeSeatByID(tripID int, passengerID int, seat int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		return db.takeSeat(tx, tripID, byID(passengerID), seat, false)
+	})
+}
+
+// Занимает для пассажира дополнительное место в поездке, где у него уже есть
+// билет: правило одного билета на поездку из поля Booking не проверяется
+func (db *AeroDB) TakeExtraSeat(tripID int, passenger string, seat int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		return db.takeSeat(tx, tripID, byName(passenger), seat, true)
+	})
+}
+
+func (db *AeroDB) TakeExtraSeatByID(tripID int, passengerID int, seat int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		return db.takeSeat(tx, tripID, byID(passengerID), seat, true)
+	})
+}
+
+func (db *AeroDB) takeSeat(tx *sql.Tx, tripID int, passenger passengerRef, seat int, extra bool) error {
+	seats, err := tripSeats(tx, tripID)
+	if err != nil {
+		return err
+	}
+	if err = checkBookable(tx, tripID); err != nil {
+		return err
+	}
+	passengerID, err := passenger.resolve(tx)
+	if err != nil {
+		return err
+	}
+	if seat < 1 || seat > seats {
+		return ErrSeatRange
+	}
+	if err = checkSeatFree(tx, tripID, seat, db.now()); err != nil {
+		return err
+	}
+	if err = db.checkPassenger(tx, tripID, passengerID, extra); err != nil {
+		return err
+	}
+	if err = db.checkTickets(tx, tripID, 1); err != nil {
+		return err
+	}
+
+	return insertTicket(tx, tripID, passengerID, seat, db.now())
+}
+
+func (db *AeroDB) GetFreeSeats(tripID int) ([]int, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	free, _, err := db.openSeats(db.db, tripID, db.now())
+	if err != nil {
+		return nil, err
+	}
+	if len(free) == 0 {
+		return nil, ErrEmpty
+	}
+	return free, nil
+}
+
+// Количество свободных мест поездки Trip в SQL-запросах к таблице Trip,
+// считается так же, как в freeSeats. Параметр - текущее время для проверки броней.
+const freeSeatsCount = `((SELECT seats FROM Plane WHERE Plane.id = Trip.plane_id) -
+	(SELECT COUNT(DISTINCT place) FROM Taken WHERE Taken.trip_id = Trip.id
+		AND place BETWEEN 1 AND (SELECT seats FROM Plane WHERE Plane.id = Trip.plane_id)) -
+	(SELECT COUNT(*) FROM Hold WHERE Hold.trip_id = Trip.id AND ` + activeHold + `
+		AND place BETWEEN 1 AND (SELECT seats FROM Plane WHERE Plane.id = Trip.plane_id)
+		AND place NOT IN (SELECT place FROM Taken WHERE Taken.trip_id = Trip.id AND place IS NOT NULL)) -
+	(SELECT COUNT(*) FROM Seat WHERE Seat.plane_id = Trip.plane_id AND attrs & 1 <> 0
+		AND place NOT IN (SELECT place FROM Taken WHERE Taken.trip_id = Trip.id AND place IS NOT NULL)))`
+
+// Свободные места поездки на момент now, пустой слайс - все места заняты,
+// удерживаются бронями или заблокированы
+func freeSeats(q querier, tripID int, now time.Time) ([]int, error) {
+	seats, err := tripSeats(q, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	taken, err := queryInts(q, "SELECT place FROM Taken WHERE trip_id = ? AND place IS NOT NULL", tripID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	held, err := queryInts(q, "SELECT place FROM Hold WHERE trip_id = ? AND "+activeHold, tripID, now)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	blocked, err := queryInts(q, `SELECT s.place FROM Seat s JOIN Trip t ON t.plane_id = s.plane_id
+		WHERE t.id = ? AND s.attrs & ? <> 0`, tripID, SeatBlocked)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	taken = append(append(taken, held...), blocked...)
+	isTaken := make(map[int]bool, len(taken))
+	for _, place := range taken {
+		isTaken[place] = true
+	}
+
+	free := []int{}
+	for seat := 1; seat <= seats; seat++ {
+		if !isTaken[seat] {
+			free = append(free, seat)
+		}
+	}
+	return free, nil
+}
+
+// Освобождает занятое место поездки и отдаёт его первому подходящему
+// пассажиру из очереди ожидания
+func (db *AeroDB) ReleaseSeat(tripID int, seat int) error {
+	var promoted []Promotion
+	err := db.inTx(func(tx *sql.Tx) error {
+		seats, err := tripSeats(tx, tripID)
+		if err != nil {
+			return err
+		}
+		if err = checkBookable(tx, tripID); err != nil {
+			return err
+		}
+		if seat < 1 || seat > seats {
+			return ErrSeatRange
+		}
+		res, err := tx.Exec("DELETE FROM Taken WHERE trip_id = ? AND place = ?", tripID, seat)
+		if err != nil {
+			return dbError(err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return dbError(err)
+		} else if n == 0 {
+			return ErrNotFound
+		}
+		p, err := db.promoteWaitlist(tx, tripID, seat, db.now())
+		if p != nil {
+			promoted = append(promoted, *p)
+		}
+		return err
+	})
+	if err == nil {
+		db.notify(promoted)
+	}
+	return err
+}
+
+// Пересаживает пассажира на место newSeat. Пассажир должен занимать
+// в поездке ровно одно место, иначе используется MoveSeat.
+func (db *AeroDB) ChangeSeat(tripID int, passenger string, newSeat int) error {
+	return db.changeSeat(tripID, byName(passenger), newSeat)
+}
+
+func (db *AeroDB) ChangeSeatByID(tripID int, passengerID int, newSeat int) error {
+	return db.changeSeat(tripID, byID(passengerID), newSeat)
+}
+
+func (db *AeroDB) changeSeat(tripID int, passenger passengerRef, newSeat int) error {
+	var promoted *Promotion
+	err := db.inTx(func(tx *sql.Tx) error {
+		if _, err := tripSeats(tx, tripID); err != nil {
+			return err
+		}
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+		places, err := queryInts(tx, "SELECT place FROM Taken WHERE trip_id = ? AND passenger_id = ? AND place IS NOT NULL", tripID, passengerID)
+		if err != nil {
+			return dbError(err)
+		}
+		switch len(places) {
+		case 0:
+			return ErrNotFound
+		case 1:
+		default:
+			return fmt.Errorf("%w: %s holds %d seats on trip %d", ErrManySeats, passenger, len(places), tripID)
+		}
+		promoted, err = db.moveSeat(tx, tripID, places[0], newSeat, db.now())
+		return err
+	})
+	if err == nil && promoted != nil {
+		db.notify([]Promotion{*promoted})
+	}
+	return err
+}
+
+// Переносит бронь с места seat на место newSeat
+func (db *AeroDB) MoveSeat(tripID int, seat, newSeat int) error {
+	var promoted *Promotion
+	err := db.inTx(func(tx *sql.Tx) (err error) {
+		promoted, err = db.moveSeat(tx, tripID, seat, newSeat, db.now())
+		return err
+	})
+	if err == nil && promoted != nil {
+		db.notify([]Promotion{*promoted})
+	}
+	return err
+}
+
+// Освободившееся место seat отдаётся пассажиру из очереди ожидания
+func (db *AeroDB) moveSeat(tx *sql.Tx, tripID int, seat, newSeat int, now time.Time) (*Promotion, error) {
+	seats, err := tripSeats(tx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkBookable(tx, tripID); err != nil {
+		return nil, err
+	}
+	if seat < 1 || seat > seats || newSeat < 1 || newSeat > seats {
+		return nil, ErrSeatRange
+	}
+	if seat == newSeat {
+		return nil, nil
+	}
+	if err = checkSeatFree(tx, tripID, newSeat, now); err != nil {
+		return nil, err
+	}
+
+	res, err := tx.Exec("UPDATE Taken SET place = ? WHERE trip_id = ? AND place = ?", newSeat, tripID, seat)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, dbError(err)
+	} else if n == 0 {
+		return nil, ErrNotFound
+	}
+	return db.promoteWaitlist(tx, tripID, seat, now)
+}
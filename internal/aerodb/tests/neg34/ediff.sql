@@ -0,0 +1 @@
+INSERT INTO Passenger(id,name,given_name,family_name,birth_date,doc_type,doc_number,contact) VALUES(29,'Mark Twain','Mark','Twain',NULL,'passport','4510 123456','');
@@ -1 +1 @@
-INSERT INTO Passenger(id,name) VALUES(29,'Mark');
+INSERT INTO Passenger(id,name,given_name,family_name,birth_date,doc_type,doc_number,contact) VALUES(29,'Mark','','',NULL,NULL,NULL,'');
@@ -0,0 +1 @@
+INSERT INTO Trip(id,company_id,plane_id,time_out,time_in,town_out,town_in,state,boarding_at,departed_at,arrived_at,cancelled_at,gate) VALUES(6,1,3,'2024-02-11 09:00:00+00:00','2024-02-11 14:28:00+00:00','Moscow','Tokyo','scheduled',NULL,NULL,NULL,NULL,'');
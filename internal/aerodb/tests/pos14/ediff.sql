@@ -0,0 +1 @@
+UPDATE Trip SET state='boarding', boarding_at='2023-11-14 22:13:20+00:00' WHERE id=1;
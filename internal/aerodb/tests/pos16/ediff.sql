@@ -0,0 +1,2 @@
+UPDATE Trip SET state='cancelled', cancelled_at='2023-11-14 22:13:20+00:00' WHERE id=2;
+INSERT INTO Trip(id,company_id,plane_id,time_out,time_in,town_out,town_in,state,boarding_at,departed_at,arrived_at,cancelled_at,gate) VALUES(6,1,1,'2023-10-25 20:00:00+00:00','2023-10-25 23:00:00+00:00','Moscow','Berlin','scheduled',NULL,NULL,NULL,NULL,'');
@@ -0,0 +1 @@
+INSERT INTO Trip(id,company_id,plane_id,time_out,time_in,town_out,town_in,state,boarding_at,departed_at,arrived_at,cancelled_at,gate) VALUES(6,1,1,'2023-10-26 03:00:00+00:00','2023-10-26 12:00:00+00:00','New-york','Moscow','scheduled',NULL,NULL,NULL,NULL,'');
@@ -0,0 +1 @@
+DELETE FROM Taken WHERE id=1;
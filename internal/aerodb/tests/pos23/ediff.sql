@@ -0,0 +1 @@
+UPDATE Taken SET place=10 WHERE id=62;
@@ -0,0 +1,3 @@
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(131,1,2,12,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(132,1,3,3,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(133,1,24,4,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
@@ -0,0 +1,17 @@
+UPDATE Plane SET seats=16 WHERE id=3;
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(1,3,1,1,'A','business',8);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(2,3,2,1,'B','business',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(3,3,3,1,'C','business',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(4,3,4,1,'D','business',8);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(5,3,5,2,'A','economy',10);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(6,3,6,2,'B','economy',2);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(7,3,7,2,'C','economy',6);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(8,3,8,2,'D','economy',6);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(9,3,9,2,'E','economy',2);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(10,3,10,2,'F','economy',10);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(11,3,11,3,'A','economy',8);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(12,3,12,3,'B','economy',0);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(13,3,13,3,'C','economy',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(14,3,14,3,'D','economy',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(15,3,15,3,'E','economy',0);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(16,3,16,3,'F','economy',9);
@@ -0,0 +1,3 @@
+DELETE FROM Taken WHERE id=131;
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(133,6,24,1,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
+INSERT INTO Waitlist(id,trip_id,passenger_id,cabin,position) VALUES(1,6,3,'',2);
@@ -0,0 +1 @@
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(133,6,3,NULL,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
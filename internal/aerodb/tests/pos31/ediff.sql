@@ -0,0 +1,5 @@
+INSERT INTO Fare(id,trip_id,cabin,base) VALUES(1,1,'economy',500000);
+INSERT INTO Fare(id,trip_id,cabin,base) VALUES(2,1,'business',2000000);
+INSERT INTO FareTier(id,fare_id,load_factor,price) VALUES(1,1,15,750000);
+INSERT INTO FareTier(id,fare_id,load_factor,price) VALUES(2,1,50,1000000);
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(131,1,2,12,750000,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
@@ -0,0 +1,3 @@
+INSERT INTO Passenger(id,name,given_name,family_name,birth_date,doc_type,doc_number,contact) VALUES(29,'Mark Twain','Mark','Twain','1990-05-17','passport','4510 123456','');
+INSERT INTO Passenger(id,name,given_name,family_name,birth_date,doc_type,doc_number,contact) VALUES(30,'Mark Twain','Mark','Twain',NULL,'passport','4510 654321','+7 900 000-00-00');
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(131,1,30,12,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
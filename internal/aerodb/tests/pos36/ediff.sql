@@ -0,0 +1,3 @@
+UPDATE Taken SET status='checked-in', checkin_seq=1, checked_in_at='2023-11-14 22:13:20+00:00' WHERE id=131;
+UPDATE Taken SET place=1, status='checked-in', checkin_seq=2, checked_in_at='2023-11-14 22:13:20+00:00' WHERE id=132;
+UPDATE Trip SET gate='A5' WHERE id=6;
@@ -0,0 +1,4 @@
+UPDATE Taken SET status='boarded', boarded_at='2023-11-14 22:13:20+00:00' WHERE id=131;
+UPDATE Taken SET place=NULL, status='no-show' WHERE id=132;
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(133,6,3,2,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
+DELETE FROM Waitlist WHERE id=1;
@@ -0,0 +1,28 @@
+DELETE FROM Company WHERE id=5;
+DELETE FROM Plane WHERE id=9;
+DELETE FROM Taken WHERE id=106;
+DELETE FROM Taken WHERE id=107;
+DELETE FROM Taken WHERE id=108;
+DELETE FROM Taken WHERE id=109;
+DELETE FROM Taken WHERE id=110;
+DELETE FROM Taken WHERE id=111;
+DELETE FROM Taken WHERE id=112;
+DELETE FROM Taken WHERE id=113;
+DELETE FROM Taken WHERE id=114;
+DELETE FROM Taken WHERE id=115;
+DELETE FROM Taken WHERE id=116;
+DELETE FROM Taken WHERE id=117;
+DELETE FROM Taken WHERE id=118;
+DELETE FROM Taken WHERE id=119;
+DELETE FROM Taken WHERE id=120;
+DELETE FROM Taken WHERE id=121;
+DELETE FROM Taken WHERE id=122;
+DELETE FROM Taken WHERE id=123;
+DELETE FROM Taken WHERE id=124;
+DELETE FROM Taken WHERE id=125;
+DELETE FROM Taken WHERE id=126;
+DELETE FROM Taken WHERE id=127;
+DELETE FROM Taken WHERE id=128;
+DELETE FROM Taken WHERE id=129;
+DELETE FROM Taken WHERE id=130;
+DELETE FROM Trip WHERE id=5;
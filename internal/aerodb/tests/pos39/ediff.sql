@@ -0,0 +1,5 @@
+DELETE FROM Plane WHERE id=7;
+UPDATE Taken SET place=1 WHERE id=83;
+UPDATE Taken SET place=5 WHERE id=94;
+UPDATE Taken SET place=3 WHERE id=99;
+UPDATE Trip SET plane_id=8 WHERE id=4;
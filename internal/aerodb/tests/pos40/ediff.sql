@@ -0,0 +1,7 @@
+UPDATE Taken SET place=5 WHERE id=81;
+UPDATE Taken SET place=6 WHERE id=83;
+UPDATE Taken SET place=8 WHERE id=94;
+UPDATE Taken SET place=3 WHERE id=96;
+UPDATE Taken SET place=7 WHERE id=99;
+UPDATE Taken SET place=1 WHERE id=102;
+UPDATE Trip SET plane_id=6 WHERE id=4;
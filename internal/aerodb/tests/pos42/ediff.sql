@@ -0,0 +1,2 @@
+DELETE FROM Plane WHERE id=7;
+UPDATE Trip SET plane_id=8 WHERE id=4;
@@ -0,0 +1,3 @@
+UPDATE Taken SET passenger_id=30, booked_at='2023-11-14 22:13:20+00:00' WHERE id=132;
+INSERT INTO Taken(id,trip_id,passenger_id,place,price,booked_at,status,checkin_seq,checked_in_at,boarded_at) VALUES(133,1,29,7,NULL,'2023-11-14 22:13:20+00:00','booked',NULL,NULL,NULL);
+INSERT INTO Waitlist(id,trip_id,passenger_id,cabin,position) VALUES(3,6,3,'',3);
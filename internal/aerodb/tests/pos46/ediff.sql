@@ -0,0 +1 @@
+INSERT INTO Waitlist(id,trip_id,passenger_id,cabin,position) VALUES(1,6,4,'',1);
@@ -0,0 +1,14 @@
+UPDATE Plane SET seats=12 WHERE id=4;
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(1,4,1,1,'A','economy',8);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(2,4,2,1,'B','economy',0);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(3,4,3,1,'C','economy',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(4,4,4,1,'D','economy',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(5,4,5,1,'E','economy',0);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(6,4,6,1,'F','economy',8);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(7,4,7,2,'A','economy',8);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(8,4,8,2,'B','economy',0);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(9,4,9,2,'C','economy',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(10,4,10,2,'D','economy',4);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(11,4,11,2,'E','economy',1);
+INSERT INTO Seat(id,plane_id,place,row_num,letter,cabin,attrs) VALUES(12,4,12,2,'F','economy',8);
+UPDATE Trip SET state='arrived', boarding_at='2023-11-14 22:13:20+00:00', departed_at='2023-11-14 22:13:20+00:00', arrived_at='2023-11-14 22:13:20+00:00' WHERE id=1;
@@ -0,0 +1,5 @@
+UPDATE Trip SET state='arrived', boarding_at='2023-11-14 22:13:20+00:00', departed_at='2023-11-14 22:13:20+00:00', arrived_at='2023-11-14 22:13:20+00:00' WHERE id=1;
+UPDATE Trip SET state='arrived', boarding_at='2023-11-14 22:13:20+00:00', departed_at='2023-11-14 22:13:20+00:00', arrived_at='2023-11-14 22:13:20+00:00' WHERE id=2;
+UPDATE Trip SET state='arrived', boarding_at='2023-11-14 22:13:20+00:00', departed_at='2023-11-14 22:13:20+00:00', arrived_at='2023-11-14 22:13:20+00:00' WHERE id=3;
+UPDATE Trip SET state='arrived', boarding_at='2023-11-14 22:13:20+00:00', departed_at='2023-11-14 22:13:20+00:00', arrived_at='2023-11-14 22:13:20+00:00' WHERE id=4;
+UPDATE Trip SET state='arrived', boarding_at='2023-11-14 22:13:20+00:00', departed_at='2023-11-14 22:13:20+00:00', arrived_at='2023-11-14 22:13:20+00:00' WHERE id=5;
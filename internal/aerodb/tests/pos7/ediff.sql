@@ -1,31 +1 @@
-DELETE FROM Taken WHERE id=1;
-DELETE FROM Taken WHERE id=2;
-DELETE FROM Taken WHERE id=3;
-DELETE FROM Taken WHERE id=4;
-DELETE FROM Taken WHERE id=5;
-DELETE FROM Taken WHERE id=6;
-DELETE FROM Taken WHERE id=7;
-DELETE FROM Taken WHERE id=8;
-DELETE FROM Taken WHERE id=9;
-DELETE FROM Taken WHERE id=10;
-DELETE FROM Taken WHERE id=11;
-DELETE FROM Taken WHERE id=12;
-DELETE FROM Taken WHERE id=13;
-DELETE FROM Taken WHERE id=14;
-DELETE FROM Taken WHERE id=15;
-DELETE FROM Taken WHERE id=16;
-DELETE FROM Taken WHERE id=17;
-DELETE FROM Taken WHERE id=18;
-DELETE FROM Taken WHERE id=19;
-DELETE FROM Taken WHERE id=20;
-DELETE FROM Taken WHERE id=21;
-DELETE FROM Taken WHERE id=22;
-DELETE FROM Taken WHERE id=23;
-DELETE FROM Taken WHERE id=24;
-DELETE FROM Taken WHERE id=25;
-DELETE FROM Taken WHERE id=26;
-DELETE FROM Taken WHERE id=27;
-DELETE FROM Taken WHERE id=28;
-DELETE FROM Taken WHERE id=29;
-DELETE FROM Taken WHERE id=30;
-DELETE FROM Trip WHERE id=1;
+UPDATE Trip SET state='arrived', boarding_at='2023-11-14 22:13:20+00:00', departed_at='2023-11-14 22:13:20+00:00', arrived_at='2023-11-14 22:13:20+00:00' WHERE id=1;
@@ -0,0 +1,118 @@
+package aerodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Создаёт поездку без проверки данных, проверку выполняет PlanTrip.
+// id = 0 - id будет выбран при добавлении в базу.
+func CreateTrip(id, company, plane int, timeOut, timeIn time.Time, townOut, townIn string) Trip {
+	return Trip{
+		id:      id,
+		company: company,
+		plane:   plane,
+		timeOut: timeOut,
+		timeIn:  timeIn,
+		townOut: townOut,
+		townIn:  townIn,
+		state:   StateScheduled,
+	}
+}
+
+// Создаёт поездку, проверяя данные: id >= 0, id компании и самолёта > 0,
+// непустые города и timeOut раньше timeIn
+func NewTrip(id, company, plane int, timeOut, timeIn time.Time, townOut, townIn string) (Trip, error) {
+	trip := CreateTrip(id, company, plane, timeOut, timeIn, townOut, townIn)
+	if err := trip.validate(); err != nil {
+		return Trip{}, err
+	}
+	return trip, nil
+}
+
+func (t Trip) validate() error {
+	switch {
+	case t.id < 0:
+		return fmt.Errorf("%w: negative trip id %d", ErrIncorrectTrip, t.id)
+	case t.company <= 0:
+		return fmt.Errorf("%w: company id %d", ErrIncorrectTrip, t.company)
+	case t.plane <= 0:
+		return fmt.Errorf("%w: plane id %d", ErrIncorrectTrip, t.plane)
+	case t.townOut == "":
+		return fmt.Errorf("%w: empty town out", ErrIncorrectTrip)
+	case t.townIn == "":
+		return fmt.Errorf("%w: empty town in", ErrIncorrectTrip)
+	case !t.timeOut.Before(t.timeIn):
+		return ErrIncorectTime
+	}
+	return nil
+}
+
+func (t Trip) ID() int                 { return t.id }
+func (t Trip) Company() int            { return t.company }
+func (t Trip) Plane() int              { return t.plane }
+func (t Trip) TimeOut() time.Time      { return t.timeOut }
+func (t Trip) TimeIn() time.Time       { return t.timeIn }
+func (t Trip) TownOut() string         { return t.townOut }
+func (t Trip) TownIn() string          { return t.townIn }
+func (t Trip) Duration() time.Duration { return t.timeIn.Sub(t.timeOut) }
+func (t Trip) State() TripState        { return t.state }
+
+// Время перехода поездки в состояние state, нулевое если перехода не было
+func (t Trip) StateTime(state TripState) time.Time {
+	switch state {
+	case StateBoarding:
+		return t.boardingAt
+	case StateDeparted:
+		return t.departedAt
+	case StateArrived:
+		return t.arrivedAt
+	case StateCancelled:
+		return t.cancelledAt
+	}
+	return time.Time{}
+}
+
+func (t Trip) String() string {
+	return fmt.Sprintf("Trip %d: company %d, plane %d, %s %s -> %s %s",
+		t.id, t.company, t.plane,
+		t.townOut, t.timeOut.Format(time.RFC3339),
+		t.townIn, t.timeIn.Format(time.RFC3339))
+}
+
+type tripJSON struct {
+	ID      int       `json:"id"`
+	Company int       `json:"company"`
+	Plane   int       `json:"plane"`
+	TimeOut time.Time `json:"time_out"`
+	TimeIn  time.Time `json:"time_in"`
+	TownOut string    `json:"town_out"`
+	TownIn  string    `json:"town_in"`
+	State   TripState `json:"state"`
+}
+
+func (t Trip) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tripJSON{
+		ID:      t.id,
+		Company: t.company,
+		Plane:   t.plane,
+		TimeOut: t.timeOut,
+		TimeIn:  t.timeIn,
+		TownOut: t.townOut,
+		TownIn:  t.townIn,
+		State:   t.state,
+	})
+}
+
+func (t *Trip) UnmarshalJSON(b []byte) error {
+	var j tripJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	*t = CreateTrip(j.ID, j.Company, j.Plane, j.TimeOut, j.TimeIn, j.TownOut, j.TownIn)
+	if j.State != "" {
+		t.state = j.State
+	}
+	return nil
+}
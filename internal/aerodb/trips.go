@@ -0,0 +1,132 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+const tripColumns = `id, company_id, plane_id, time_out, time_in, town_out, town_in,
+	state, boarding_at, departed_at, arrived_at, cancelled_at`
+
+func scanTrip(rows *sql.Rows) (Trip, error) {
+	return scanTripWith(rows)
+}
+
+// Читает поездку из столбцов tripColumns и следующие за ними столбцы в extra
+func scanTripWith(rows *sql.Rows, extra ...interface{}) (Trip, error) {
+	var t Trip
+	var boarding, departed, arrived, cancelled sql.NullTime
+	dest := []interface{}{&t.id, &t.company, &t.plane, &t.timeOut, &t.timeIn, &t.townOut, &t.townIn,
+		&t.state, &boarding, &departed, &arrived, &cancelled}
+	err := rows.Scan(append(dest, extra...)...)
+	t.boardingAt, t.departedAt = boarding.Time, departed.Time
+	t.arrivedAt, t.cancelledAt = arrived.Time, cancelled.Time
+	return t, err
+}
+
+func sortByDeparture(trips []Trip) {
+	sort.SliceStable(trips, func(i, j int) bool {
+		return trips[i].timeOut.Before(trips[j].timeOut)
+	})
+}
+
+func (db *AeroDB) PlanTrip(trip Trip) (int, error) {
+	var id int64
+	err := db.inTx(func(tx *sql.Tx) error {
+		ok, err := exists(tx, "SELECT 1 FROM Company WHERE id = ?", trip.company)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNotFound
+		}
+		ok, err = exists(tx, "SELECT 1 FROM Plane WHERE id = ?", trip.plane)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrNotFound
+		}
+		if err = trip.validate(); err != nil {
+			return err
+		}
+		if err = checkPlaneFree(tx, trip.plane, trip.timeOut, trip.timeIn, 0); err != nil {
+			return err
+		}
+
+		// Занятый или неуказанный id заменяется первым свободным
+		var tripID interface{}
+		if trip.id > 0 {
+			taken, err := exists(tx, "SELECT 1 FROM Trip WHERE id = ?", trip.id)
+			if err != nil {
+				return err
+			}
+			if !taken {
+				tripID = trip.id
+			}
+		}
+
+		res, err := tx.Exec(`INSERT INTO Trip(id, company_id, plane_id, time_out, time_in, town_out, town_in)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			tripID, trip.company, trip.plane, trip.timeOut, trip.timeIn, trip.townOut, trip.townIn)
+		if err != nil {
+			return dbError(err)
+		}
+		id, err = res.LastInsertId()
+		if err != nil {
+			return dbError(err)
+		}
+
+		if db.Rules != nil {
+			planned := trip
+			planned.id = int(id)
+			return db.Rules.checkTrip(tx, planned)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// Принудительно завершает поездку, переводя её в состояние arrived из любого
+// незавершённого состояния в обход tripTransitions. Пропущенные состояния
+// boarding и departed получают время завершения. Поездка и занятые места
+// остаются в базе, удалить их можно методом PurgeTrip.
+func (db *AeroDB) EndTrip(tripID int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		current, err := tripState(tx, tripID)
+		if err != nil {
+			return err
+		}
+		if current.Final() {
+			return fmt.Errorf("%w: %s -> %s", ErrTripState, current, StateArrived)
+		}
+		now := db.now()
+		_, err = tx.Exec(`UPDATE Trip SET state = ?, arrived_at = ?,
+			boarding_at = IFNULL(boarding_at, ?), departed_at = IFNULL(departed_at, ?)
+			WHERE id = ?`, StateArrived, now, now, now, tripID)
+		if err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+func (db *AeroDB) GetTrips(from, to string) ([]Trip, error) {
+	it, err := db.IterTrips(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return it.collect()
+}
+
+func (db *AeroDB) GetAllTrips() ([]Trip, error) {
+	it, err := db.IterAllTrips()
+	if err != nil {
+		return nil, err
+	}
+	return it.collect()
+}
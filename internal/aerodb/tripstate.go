@@ -0,0 +1,110 @@
+package aerodb
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type TripState string
+
+const (
+	StateScheduled TripState = "scheduled"
+	StateBoarding  TripState = "boarding"
+	StateDeparted  TripState = "departed"
+	StateArrived   TripState = "arrived"
+	StateCancelled TripState = "cancelled"
+)
+
+// Допустимые переходы между состояниями поездки
+var tripTransitions = map[TripState][]TripState{
+	StateScheduled: {StateBoarding, StateCancelled},
+	StateBoarding:  {StateDeparted, StateCancelled},
+	StateDeparted:  {StateArrived},
+}
+
+// Столбцы Trip со временем перехода в состояние
+var stateColumns = map[TripState]string{
+	StateBoarding:  "boarding_at",
+	StateDeparted:  "departed_at",
+	StateArrived:   "arrived_at",
+	StateCancelled: "cancelled_at",
+}
+
+func (s TripState) Valid() bool {
+	return s == StateScheduled || stateColumns[s] != ""
+}
+
+// Завершённая поездка больше не меняет состояние
+func (s TripState) Final() bool {
+	return s == StateArrived || s == StateCancelled
+}
+
+func (s TripState) CanMoveTo(next TripState) bool {
+	for _, st := range tripTransitions[s] {
+		if st == next {
+			return true
+		}
+	}
+	return false
+}
+
+func tripState(q querier, tripID int) (TripState, error) {
+	var state TripState
+	err := q.QueryRow("SELECT state FROM Trip WHERE id = ?", tripID).Scan(&state)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", dbError(err)
+	}
+	return state, nil
+}
+
+func (db *AeroDB) markTripState(tx *sql.Tx, tripID int, state TripState) error {
+	_, err := tx.Exec("UPDATE Trip SET state = ?, "+stateColumns[state]+" = ? WHERE id = ?", state, db.now(), tripID)
+	if err != nil {
+		return dbError(err)
+	}
+	return nil
+}
+
+// Переводит поездку в состояние state, если такой переход допустим
+func (db *AeroDB) SetTripState(tripID int, state TripState) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		current, err := tripState(tx, tripID)
+		if err != nil {
+			return err
+		}
+		if !current.CanMoveTo(state) {
+			return fmt.Errorf("%w: %s -> %s", ErrTripState, current, state)
+		}
+		return db.markTripState(tx, tripID, state)
+	})
+}
+
+// Удаляет поездку из базы вместе с занятыми в ней местами
+func (db *AeroDB) PurgeTrip(tripID int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("DELETE FROM Taken WHERE trip_id = ?", tripID); err != nil {
+			return dbError(err)
+		}
+		res, err := tx.Exec("DELETE FROM Trip WHERE id = ?", tripID)
+		if err != nil {
+			return dbError(err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return dbError(err)
+		} else if n == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (db *AeroDB) GetTripsByState(state TripState) ([]Trip, error) {
+	it, err := db.IterTripsByState(state)
+	if err != nil {
+		return nil, err
+	}
+	return it.collect()
+}
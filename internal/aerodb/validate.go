@@ -0,0 +1,327 @@
+package aerodb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Ошибка формата базы: список всех найденных расхождений со схемой
+type SchemaError struct {
+	Problems []string
+}
+
+func (e *SchemaError) Error() string {
+	return ErrDBFormat.Error() + ": " + strings.Join(e.Problems, "; ")
+}
+
+func (e *SchemaError) Unwrap() error {
+	return ErrDBFormat
+}
+
+type columnInfo struct {
+	ctype   string
+	notNull bool
+	pk      int
+}
+
+type indexKey struct {
+	columns string
+	unique  bool
+}
+
+type tableSchema struct {
+	columns     map[string]columnInfo
+	order       []string
+	foreignKeys map[string]bool // "from -> Table(to)"
+	indexes     map[indexKey]bool
+}
+
+// Проверяет файл базы данных на соответствие схеме его версии, не изменяя его.
+// Возвращает ErrFile, если файл не удалось открыть, ErrSchemaVersion, если
+// схема новее поддерживаемой, и *SchemaError (errors.Is(err, ErrDBFormat)),
+// если схема не совпадает.
+func ValidateDB(fname string) error {
+	if st, err := os.Stat(fname); err != nil || st.IsDir() {
+		return ErrFile
+	}
+	conn, err := openFile(fname, "ro")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return formatError(validateSchema(conn))
+}
+
+func openFile(fname, mode string) (*sql.DB, error) {
+	dsn := "file:" + fname + "?mode=" + mode + "&_foreign_keys=1"
+	if mode == "rw" {
+		// В режиме WAL открытый курсор не блокирует запись в базу
+		dsn += "&_journal_mode=WAL"
+	}
+	conn, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, ErrFile
+	}
+	if err = conn.Ping(); err != nil {
+		conn.Close()
+		if isNotADB(err) {
+			return nil, formatError(err)
+		}
+		return nil, ErrFile
+	}
+	return conn, nil
+}
+
+// Файл, который не является базой sqlite, - ошибка формата
+func formatError(err error) error {
+	if isNotADB(err) {
+		return fmt.Errorf("%w: %v", ErrDBFormat, err)
+	}
+	return err
+}
+
+func isNotADB(err error) bool {
+	var serr sqlite3.Error
+	return errors.As(err, &serr) && serr.Code == sqlite3.ErrNotADB
+}
+
+// Сравнивает схему базы со схемой, которую дают миграции её версии.
+// База без версии должна быть пустой или совпадать с исходной схемой
+// (созданной до появления миграций).
+func validateSchema(q querier) error {
+	version, err := schemaVersion(q)
+	if err != nil {
+		return err
+	}
+	if latest := LatestSchemaVersion(); version > latest {
+		return fmt.Errorf("%w: database version %d, supported %d", ErrSchemaVersion, version, latest)
+	}
+	actual, err := readSchema(q)
+	if err != nil {
+		return dbError(err)
+	}
+
+	legacy := false
+	if version == 0 {
+		if len(actual) == 0 {
+			return nil
+		}
+		version, legacy = 1, true
+	}
+	expected, err := expectedSchema(version)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		if legacy && name == "schema_version" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		exp, act := expected[name], actual[name]
+		if act == nil {
+			problems = append(problems, fmt.Sprintf("table %s is missing", name))
+			continue
+		}
+		for _, col := range exp.order {
+			ec := exp.columns[col]
+			ac, ok := act.columns[col]
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("column %s.%s is missing", name, col))
+			case !strings.EqualFold(ac.ctype, ec.ctype):
+				problems = append(problems, fmt.Sprintf("column %s.%s has type %q, expected %q", name, col, ac.ctype, ec.ctype))
+			case ac.pk != ec.pk:
+				problems = append(problems, fmt.Sprintf("column %s.%s primary key mismatch", name, col))
+			case ac.notNull != ec.notNull:
+				problems = append(problems, fmt.Sprintf("column %s.%s not null mismatch", name, col))
+			}
+		}
+		for _, col := range act.order {
+			if _, ok := exp.columns[col]; !ok {
+				problems = append(problems, fmt.Sprintf("column %s.%s is unexpected", name, col))
+			}
+		}
+		for _, fk := range sortedKeys(exp.foreignKeys) {
+			if !act.foreignKeys[fk] {
+				problems = append(problems, fmt.Sprintf("foreign key %s.%s is missing", name, fk))
+			}
+		}
+		var missing []string
+		for idx := range exp.indexes {
+			if act.indexes[idx] {
+				continue
+			}
+			kind := "index"
+			if idx.unique {
+				kind = "unique index"
+			}
+			missing = append(missing, fmt.Sprintf("%s on %s(%s) is missing", kind, name, idx.columns))
+		}
+		sort.Strings(missing)
+		problems = append(problems, missing...)
+	}
+
+	if len(problems) > 0 {
+		return &SchemaError{Problems: problems}
+	}
+	return nil
+}
+
+// Эталонные схемы по версиям. Миграции встроены в библиотеку, поэтому схема
+// версии не меняется и строится один раз.
+var (
+	schemaCacheMu sync.Mutex
+	schemaCache   = make(map[int]map[string]*tableSchema)
+)
+
+// Эталонная схема версии version. Результат общий для всех вызовов и не должен изменяться.
+func expectedSchema(version int) (map[string]*tableSchema, error) {
+	schemaCacheMu.Lock()
+	defer schemaCacheMu.Unlock()
+	if schema, ok := schemaCache[version]; ok {
+		return schema, nil
+	}
+	schema, err := buildSchema(version)
+	if err != nil {
+		return nil, err
+	}
+	schemaCache[version] = schema
+	return schema, nil
+}
+
+// Строит схему версии version применением миграций к пустой базе в памяти
+func buildSchema(version int) (map[string]*tableSchema, error) {
+	conn, err := sql.Open("sqlite3", ":memory:?_foreign_keys=1")
+	if err != nil {
+		return nil, dbError(err)
+	}
+	defer conn.Close()
+	// Каждое соединение с :memory: - отдельная база
+	conn.SetMaxOpenConns(1)
+
+	if err = migrateTo(conn, version); err != nil {
+		return nil, err
+	}
+	schema, err := readSchema(conn)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	return schema, nil
+}
+
+func readSchema(q querier) (map[string]*tableSchema, error) {
+	names, err := queryStrings(q, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*tableSchema)
+	for _, name := range names {
+		t := &tableSchema{
+			columns:     make(map[string]columnInfo),
+			foreignKeys: make(map[string]bool),
+			indexes:     make(map[indexKey]bool),
+		}
+
+		rows, err := q.Query("SELECT name, type, \"notnull\", pk FROM pragma_table_info(?)", name)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var col string
+			var info columnInfo
+			if err = rows.Scan(&col, &info.ctype, &info.notNull, &info.pk); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			t.columns[col] = info
+			t.order = append(t.order, col)
+		}
+		rows.Close()
+
+		rows, err = q.Query("SELECT \"from\", \"table\", IFNULL(\"to\", '') FROM pragma_foreign_key_list(?)", name)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var from, table, to string
+			if err = rows.Scan(&from, &table, &to); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			t.foreignKeys[fmt.Sprintf("%s -> %s(%s)", from, table, to)] = true
+		}
+		rows.Close()
+
+		rows, err = q.Query("SELECT name, \"unique\" FROM pragma_index_list(?) WHERE origin <> 'pk'", name)
+		if err != nil {
+			return nil, err
+		}
+		type index struct {
+			name   string
+			unique bool
+		}
+		var indexes []index
+		for rows.Next() {
+			var idx index
+			if err = rows.Scan(&idx.name, &idx.unique); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			indexes = append(indexes, idx)
+		}
+		rows.Close()
+
+		for _, idx := range indexes {
+			cols, err := queryStrings(q, "SELECT name FROM pragma_index_info(?) ORDER BY seqno", idx.name)
+			if err != nil {
+				return nil, err
+			}
+			t.indexes[indexKey{columns: strings.Join(cols, ", "), unique: idx.unique}] = true
+		}
+
+		res[name] = t
+	}
+	return res, nil
+}
+
+func queryStrings(q querier, query string, args ...interface{}) ([]string, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []string
+	for rows.Next() {
+		var s string
+		if err = rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,281 @@
+package aerodb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Пассажир в очереди ожидания поездки. Cabin = "" - подходит место любого класса.
+type WaitlistEntry struct {
+	PassengerID int
+	Passenger   string
+	Cabin       Cabin
+	Position    int
+}
+
+// Пассажир из очереди ожидания, получивший освободившееся место
+type Promotion struct {
+	Trip        int
+	Seat        int
+	PassengerID int
+	Passenger   string
+}
+
+func (p Promotion) String() string {
+	return fmt.Sprintf("trip %d: seat %d -> %s", p.Trip, p.Seat, p.Passenger)
+}
+
+// Сообщает о продвижениях очереди после успешного завершения транзакции
+func (db *AeroDB) notify(promoted []Promotion) {
+	if db.OnPromote == nil {
+		return
+	}
+	for _, p := range promoted {
+		db.OnPromote(p)
+	}
+}
+
+// Отдаёт освободившееся место первому подходящему пассажиру из очереди.
+// Пассажиры, которым правила бронирования из поля Booking не позволяют
+// получить билет, пропускаются и остаются в очереди.
+// Возвращает nil, если подходящих пассажиров нет.
+func (db *AeroDB) promoteWaitlist(tx *sql.Tx, tripID, seat int, now time.Time) (*Promotion, error) {
+	state, err := tripState(tx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if state != StateScheduled && state != StateBoarding {
+		return nil, nil
+	}
+	// Освободившееся место нужнее пассажирам с билетами без мест
+	seatless, err := exists(tx, "SELECT 1 FROM Taken WHERE trip_id = ? AND place IS NULL AND status <> ?", tripID, StatusNoShow)
+	if err != nil || seatless {
+		return nil, err
+	}
+	seatMap, err := tripSeatMap(tx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if seat < 1 || seat > len(seatMap) || seatMap[seat-1].Attrs.Has(SeatBlocked) {
+		return nil, nil
+	}
+
+	type entry struct {
+		id, passengerID int
+		name            string
+	}
+	rows, err := tx.Query(`SELECT w.id, w.passenger_id, p.name FROM Waitlist w
+		JOIN Passenger p ON p.id = w.passenger_id
+		WHERE w.trip_id = ? AND (w.cabin = '' OR w.cabin = ?)
+		ORDER BY w.position, w.id`, tripID, seatMap[seat-1].Cabin)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		if err = rows.Scan(&e.id, &e.passengerID, &e.name); err != nil {
+			rows.Close()
+			return nil, dbError(err)
+		}
+		entries = append(entries, e)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, dbError(err)
+	}
+
+	for _, e := range entries {
+		err = db.checkPassenger(tx, tripID, e.passengerID, false)
+		if errors.Is(err, ErrDuplicateTicket) || errors.Is(err, ErrPassengerBusy) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err = tx.Exec("DELETE FROM Waitlist WHERE id = ?", e.id); err != nil {
+			return nil, dbError(err)
+		}
+		if err = insertTicket(tx, tripID, e.passengerID, seat, now); err != nil {
+			return nil, err
+		}
+		return &Promotion{Trip: tripID, Seat: seat, PassengerID: e.passengerID, Passenger: e.name}, nil
+	}
+	return nil, nil
+}
+
+// Ставит пассажира в конец очереди ожидания поездки. Встать в очередь можно,
+// только если свободных мест нужного класса нет.
+func (db *AeroDB) JoinWaitlist(tripID int, passenger string, cabin Cabin) error {
+	return db.joinWaitlist(tripID, byName(passenger), cabin)
+}
+
+func (db *AeroDB) JoinWaitlistByID(tripID int, passengerID int, cabin Cabin) error {
+	return db.joinWaitlist(tripID, byID(passengerID), cabin)
+}
+
+func (db *AeroDB) joinWaitlist(tripID int, passenger passengerRef, cabin Cabin) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		seatMap, err := tripSeatMap(tx, tripID)
+		if err != nil {
+			return err
+		}
+		if err = checkBookable(tx, tripID); err != nil {
+			return err
+		}
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+		if err = db.checkPassenger(tx, tripID, passengerID, false); err != nil {
+			return err
+		}
+		if cabin != "" && !cabin.Valid() {
+			return fmt.Errorf("%w: unknown cabin %q", ErrSeatLayout, cabin)
+		}
+
+		// Места, которые нельзя купить из-за лимита билетов, не мешают встать в очередь
+		free, _, err := db.openSeats(tx, tripID, db.now())
+		if err != nil {
+			return err
+		}
+		for _, seat := range free {
+			if seat <= len(seatMap) && (cabin == "" || seatMap[seat-1].Cabin == cabin) {
+				return fmt.Errorf("%w: seat %d is free", ErrNotFull, seat)
+			}
+		}
+
+		in, err := exists(tx, "SELECT 1 FROM Waitlist WHERE trip_id = ? AND passenger_id = ?", tripID, passengerID)
+		if err != nil {
+			return err
+		}
+		if in {
+			return ErrAlreadyIn
+		}
+		_, err = tx.Exec(`INSERT INTO Waitlist(trip_id, passenger_id, cabin, position)
+			VALUES (?, ?, ?, (SELECT IFNULL(MAX(position), 0) + 1 FROM Waitlist WHERE trip_id = ?))`,
+			tripID, passengerID, cabin, tripID)
+		if err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+}
+
+// Очередь ожидания поездки по порядку, пустой слайс - очередь пуста
+func (db *AeroDB) GetWaitlist(tripID int) ([]WaitlistEntry, error) {
+	it, err := db.IterWaitlist(tripID)
+	if err != nil {
+		return nil, err
+	}
+	return it.all()
+}
+
+// Курсор по очереди ожидания, как TripIterator
+type WaitlistIterator struct {
+	cursor[WaitlistEntry]
+}
+
+func (it *WaitlistIterator) Entry() WaitlistEntry {
+	return it.cur
+}
+
+func (db *AeroDB) IterWaitlist(tripID int) (*WaitlistIterator, error) {
+	if db.db == nil {
+		return nil, ErrNotOpened
+	}
+	if _, err := tripState(db.db, tripID); err != nil {
+		return nil, err
+	}
+	position := 0
+	scan := func(rows *sql.Rows) (WaitlistEntry, error) {
+		position++
+		e := WaitlistEntry{Position: position}
+		err := rows.Scan(&e.PassengerID, &e.Passenger, &e.Cabin)
+		return e, err
+	}
+	c, err := openCursor(db.db, scan, `SELECT w.passenger_id, p.name, w.cabin FROM Waitlist w
+		JOIN Passenger p ON p.id = w.passenger_id
+		WHERE w.trip_id = ? ORDER BY w.position, w.id`, tripID)
+	if err != nil {
+		return nil, err
+	}
+	return &WaitlistIterator{c}, nil
+}
+
+// Переставляет пассажира на место position в очереди (нумерация с 1)
+func (db *AeroDB) MoveInWaitlist(tripID int, passenger string, position int) error {
+	return db.moveInWaitlist(tripID, byName(passenger), position)
+}
+
+func (db *AeroDB) MoveInWaitlistByID(tripID int, passengerID int, position int) error {
+	return db.moveInWaitlist(tripID, byID(passengerID), position)
+}
+
+func (db *AeroDB) moveInWaitlist(tripID int, passenger passengerRef, position int) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+		if _, err = tripState(tx, tripID); err != nil {
+			return err
+		}
+		ids, err := queryInts(tx, "SELECT passenger_id FROM Waitlist WHERE trip_id = ? ORDER BY position, id", tripID)
+		if err != nil {
+			return dbError(err)
+		}
+		from := -1
+		for i, id := range ids {
+			if id == passengerID {
+				from = i
+			}
+		}
+		if from < 0 {
+			return ErrNotFound
+		}
+		if position < 1 || position > len(ids) {
+			return fmt.Errorf("%w: %d of %d", ErrWaitlistPosition, position, len(ids))
+		}
+
+		ids = append(ids[:from], ids[from+1:]...)
+		ids = append(ids[:position-1], append([]int{passengerID}, ids[position-1:]...)...)
+		for i, id := range ids {
+			_, err = tx.Exec("UPDATE Waitlist SET position = ? WHERE trip_id = ? AND passenger_id = ?", i+1, tripID, id)
+			if err != nil {
+				return dbError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// Убирает пассажира из очереди ожидания поездки
+func (db *AeroDB) LeaveWaitlist(tripID int, passenger string) error {
+	return db.leaveWaitlist(tripID, byName(passenger))
+}
+
+func (db *AeroDB) LeaveWaitlistByID(tripID int, passengerID int) error {
+	return db.leaveWaitlist(tripID, byID(passengerID))
+}
+
+func (db *AeroDB) leaveWaitlist(tripID int, passenger passengerRef) error {
+	return db.inTx(func(tx *sql.Tx) error {
+		passengerID, err := passenger.resolve(tx)
+		if err != nil {
+			return err
+		}
+		res, err := tx.Exec("DELETE FROM Waitlist WHERE trip_id = ? AND passenger_id = ?", tripID, passengerID)
+		if err != nil {
+			return dbError(err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return dbError(err)
+		} else if n == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}